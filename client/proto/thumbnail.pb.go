@@ -26,7 +26,7 @@ const (
 type FileType int32
 
 const (
-	FileType_FILE_TYPE_UNSPECIFIED FileType = 0 // Default value when file type is not specified.
+	FileType_FILE_TYPE_UNSPECIFIED FileType = 0 // Default value when file type is not specified; the type is detected from the file content.
 	FileType_IMAGE                 FileType = 1 // Represents an image file type.
 	FileType_VIDEO                 FileType = 2 // Represents a video file type.
 	FileType_PDF                   FileType = 3 // Represents a PDF file type.
@@ -75,36 +75,2913 @@ func (FileType) EnumDescriptor() ([]byte, []int) {
 	return file_thumbnail_proto_rawDescGZIP(), []int{0}
 }
 
+// Enum representing the image formats a thumbnail can be encoded in.
+type OutputFormat int32
+
+const (
+	OutputFormat_OUTPUT_FORMAT_UNSPECIFIED OutputFormat = 0 // Keeps the format of an uploaded JPEG, PNG, GIF or WebP image; JPEG for all other files.
+	OutputFormat_JPEG                      OutputFormat = 1 // Encodes the thumbnail as JPEG.
+	OutputFormat_PNG                       OutputFormat = 2 // Encodes the thumbnail as PNG.
+	OutputFormat_GIF                       OutputFormat = 3 // Encodes the thumbnail as GIF.
+	OutputFormat_WEBP                      OutputFormat = 4 // Encodes the thumbnail as WebP; requires FFmpeg with libwebp.
+)
+
+// Enum value maps for OutputFormat.
+var (
+	OutputFormat_name = map[int32]string{
+		0: "OUTPUT_FORMAT_UNSPECIFIED",
+		1: "JPEG",
+		2: "PNG",
+		3: "GIF",
+		4: "WEBP",
+	}
+	OutputFormat_value = map[string]int32{
+		"OUTPUT_FORMAT_UNSPECIFIED": 0,
+		"JPEG":                      1,
+		"PNG":                       2,
+		"GIF":                       3,
+		"WEBP":                      4,
+	}
+)
+
+func (x OutputFormat) Enum() *OutputFormat {
+	p := new(OutputFormat)
+	*p = x
+	return p
+}
+
+func (x OutputFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutputFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_thumbnail_proto_enumTypes[1].Descriptor()
+}
+
+func (OutputFormat) Type() protoreflect.EnumType {
+	return &file_thumbnail_proto_enumTypes[1]
+}
+
+func (x OutputFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutputFormat.Descriptor instead.
+func (OutputFormat) EnumDescriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{1}
+}
+
+// Enum representing how a thumbnail is fitted into a box when both a
+// maximum width and a maximum height are given.
+type FitMode int32
+
+const (
+	FitMode_FIT_MODE_UNSPECIFIED FitMode = 0 // Same as FILL.
+	FitMode_CONTAIN              FitMode = 1 // Scales the image to fit inside the box, keeping its aspect ratio.
+	FitMode_COVER                FitMode = 2 // Scales the image to fill the box, keeping its aspect ratio, and crops the center.
+	FitMode_FILL                 FitMode = 3 // Stretches the image to exactly the size of the box.
+	FitMode_PAD                  FitMode = 4 // Scales like CONTAIN and letterboxes the rest of the box with the background color.
+	FitMode_SMART                FitMode = 5 // Scales like COVER but crops the most interesting region instead of the center.
+)
+
+// Enum value maps for FitMode.
+var (
+	FitMode_name = map[int32]string{
+		0: "FIT_MODE_UNSPECIFIED",
+		1: "CONTAIN",
+		2: "COVER",
+		3: "FILL",
+		4: "PAD",
+		5: "SMART",
+	}
+	FitMode_value = map[string]int32{
+		"FIT_MODE_UNSPECIFIED": 0,
+		"CONTAIN":              1,
+		"COVER":                2,
+		"FILL":                 3,
+		"PAD":                  4,
+		"SMART":                5,
+	}
+)
+
+func (x FitMode) Enum() *FitMode {
+	p := new(FitMode)
+	*p = x
+	return p
+}
+
+func (x FitMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FitMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_thumbnail_proto_enumTypes[2].Descriptor()
+}
+
+func (FitMode) Type() protoreflect.EnumType {
+	return &file_thumbnail_proto_enumTypes[2]
+}
+
+func (x FitMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FitMode.Descriptor instead.
+func (FitMode) EnumDescriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{2}
+}
+
+// Enum representing the compression levels of PNG thumbnails.
+type PngCompression int32
+
+const (
+	PngCompression_PNG_COMPRESSION_DEFAULT    PngCompression = 0 // Default compression.
+	PngCompression_PNG_COMPRESSION_NONE       PngCompression = 1 // No compression.
+	PngCompression_PNG_COMPRESSION_BEST_SPEED PngCompression = 2 // Fastest compression.
+	PngCompression_PNG_COMPRESSION_BEST       PngCompression = 3 // Smallest output.
+)
+
+// Enum value maps for PngCompression.
+var (
+	PngCompression_name = map[int32]string{
+		0: "PNG_COMPRESSION_DEFAULT",
+		1: "PNG_COMPRESSION_NONE",
+		2: "PNG_COMPRESSION_BEST_SPEED",
+		3: "PNG_COMPRESSION_BEST",
+	}
+	PngCompression_value = map[string]int32{
+		"PNG_COMPRESSION_DEFAULT":    0,
+		"PNG_COMPRESSION_NONE":       1,
+		"PNG_COMPRESSION_BEST_SPEED": 2,
+		"PNG_COMPRESSION_BEST":       3,
+	}
+)
+
+func (x PngCompression) Enum() *PngCompression {
+	p := new(PngCompression)
+	*p = x
+	return p
+}
+
+func (x PngCompression) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PngCompression) Descriptor() protoreflect.EnumDescriptor {
+	return file_thumbnail_proto_enumTypes[3].Descriptor()
+}
+
+func (PngCompression) Type() protoreflect.EnumType {
+	return &file_thumbnail_proto_enumTypes[3]
+}
+
+func (x PngCompression) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PngCompression.Descriptor instead.
+func (PngCompression) EnumDescriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{3}
+}
+
+// Enum representing the word layout formats OCR results can be returned in.
+type LayoutFormat int32
+
+const (
+	LayoutFormat_LAYOUT_FORMAT_NONE LayoutFormat = 0 // No word layout is returned.
+	LayoutFormat_HOCR               LayoutFormat = 1 // Returns the layout as hOCR and in the layout field.
+	LayoutFormat_ALTO               LayoutFormat = 2 // Returns the layout as ALTO v4 XML and in the layout field.
+)
+
+// Enum value maps for LayoutFormat.
+var (
+	LayoutFormat_name = map[int32]string{
+		0: "LAYOUT_FORMAT_NONE",
+		1: "HOCR",
+		2: "ALTO",
+	}
+	LayoutFormat_value = map[string]int32{
+		"LAYOUT_FORMAT_NONE": 0,
+		"HOCR":               1,
+		"ALTO":               2,
+	}
+)
+
+func (x LayoutFormat) Enum() *LayoutFormat {
+	p := new(LayoutFormat)
+	*p = x
+	return p
+}
+
+func (x LayoutFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LayoutFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_thumbnail_proto_enumTypes[4].Descriptor()
+}
+
+func (LayoutFormat) Type() protoreflect.EnumType {
+	return &file_thumbnail_proto_enumTypes[4]
+}
+
+func (x LayoutFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LayoutFormat.Descriptor instead.
+func (LayoutFormat) EnumDescriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{4}
+}
+
+// Enum representing which pages of a PDF are OCRed.
+type OcrMode int32
+
+const (
+	OcrMode_OCR_MODE_AUTO OcrMode = 0 // OCRs the pages with (almost) no text layer, see OCRFileResponse.
+	OcrMode_SKIP_TEXT     OcrMode = 1 // OCRs only the pages without any text; pages with text pass through unchanged.
+	OcrMode_FORCE_OCR     OcrMode = 2 // Rasterizes and OCRs every page; existing text is replaced.
+	OcrMode_REDO_OCR      OcrMode = 3 // OCRs every page again, keeping vector text and replacing only old OCR text.
+)
+
+// Enum value maps for OcrMode.
+var (
+	OcrMode_name = map[int32]string{
+		0: "OCR_MODE_AUTO",
+		1: "SKIP_TEXT",
+		2: "FORCE_OCR",
+		3: "REDO_OCR",
+	}
+	OcrMode_value = map[string]int32{
+		"OCR_MODE_AUTO": 0,
+		"SKIP_TEXT":     1,
+		"FORCE_OCR":     2,
+		"REDO_OCR":      3,
+	}
+)
+
+func (x OcrMode) Enum() *OcrMode {
+	p := new(OcrMode)
+	*p = x
+	return p
+}
+
+func (x OcrMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OcrMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_thumbnail_proto_enumTypes[5].Descriptor()
+}
+
+func (OcrMode) Type() protoreflect.EnumType {
+	return &file_thumbnail_proto_enumTypes[5]
+}
+
+func (x OcrMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OcrMode.Descriptor instead.
+func (OcrMode) EnumDescriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{5}
+}
+
+// Enum representing the optimization ocrmypdf applies to the output PDF.
+type OptimizeLevel int32
+
+const (
+	OptimizeLevel_OPTIMIZE_DEFAULT    OptimizeLevel = 0 // Lossless optimizations, the ocrmypdf default.
+	OptimizeLevel_OPTIMIZE_NONE       OptimizeLevel = 1 // No optimization.
+	OptimizeLevel_OPTIMIZE_LOSSLESS   OptimizeLevel = 2 // Lossless optimizations.
+	OptimizeLevel_OPTIMIZE_LOSSY      OptimizeLevel = 3 // Lossy JPEG and PNG optimizations.
+	OptimizeLevel_OPTIMIZE_AGGRESSIVE OptimizeLevel = 4 // Aggressive lossy optimizations; may visibly reduce image quality.
+)
+
+// Enum value maps for OptimizeLevel.
+var (
+	OptimizeLevel_name = map[int32]string{
+		0: "OPTIMIZE_DEFAULT",
+		1: "OPTIMIZE_NONE",
+		2: "OPTIMIZE_LOSSLESS",
+		3: "OPTIMIZE_LOSSY",
+		4: "OPTIMIZE_AGGRESSIVE",
+	}
+	OptimizeLevel_value = map[string]int32{
+		"OPTIMIZE_DEFAULT":    0,
+		"OPTIMIZE_NONE":       1,
+		"OPTIMIZE_LOSSLESS":   2,
+		"OPTIMIZE_LOSSY":      3,
+		"OPTIMIZE_AGGRESSIVE": 4,
+	}
+)
+
+func (x OptimizeLevel) Enum() *OptimizeLevel {
+	p := new(OptimizeLevel)
+	*p = x
+	return p
+}
+
+func (x OptimizeLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OptimizeLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_thumbnail_proto_enumTypes[6].Descriptor()
+}
+
+func (OptimizeLevel) Type() protoreflect.EnumType {
+	return &file_thumbnail_proto_enumTypes[6]
+}
+
+func (x OptimizeLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OptimizeLevel.Descriptor instead.
+func (OptimizeLevel) EnumDescriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{6}
+}
+
+// Enum representing the type of PDF the OCR writes.
+type OcrOutputType int32
+
+const (
+	OcrOutputType_OCR_OUTPUT_TYPE_UNSPECIFIED OcrOutputType = 0 // PDF/A-2b if any page is OCRed, the ocrmypdf default; born-digital PDFs are returned unchanged.
+	OcrOutputType_OCR_OUTPUT_PDF              OcrOutputType = 1 // Regular PDF; keeps the file closest to the input.
+	OcrOutputType_OCR_OUTPUT_PDFA             OcrOutputType = 2 // PDF/A-2b for long term archiving, also for born-digital PDFs.
+	OcrOutputType_OCR_OUTPUT_PDFA_1           OcrOutputType = 3 // PDF/A-1b, also for born-digital PDFs.
+	OcrOutputType_OCR_OUTPUT_PDFA_2           OcrOutputType = 4 // PDF/A-2b, also for born-digital PDFs.
+	OcrOutputType_OCR_OUTPUT_PDFA_3           OcrOutputType = 5 // PDF/A-3b, also for born-digital PDFs.
+)
+
+// Enum value maps for OcrOutputType.
+var (
+	OcrOutputType_name = map[int32]string{
+		0: "OCR_OUTPUT_TYPE_UNSPECIFIED",
+		1: "OCR_OUTPUT_PDF",
+		2: "OCR_OUTPUT_PDFA",
+		3: "OCR_OUTPUT_PDFA_1",
+		4: "OCR_OUTPUT_PDFA_2",
+		5: "OCR_OUTPUT_PDFA_3",
+	}
+	OcrOutputType_value = map[string]int32{
+		"OCR_OUTPUT_TYPE_UNSPECIFIED": 0,
+		"OCR_OUTPUT_PDF":              1,
+		"OCR_OUTPUT_PDFA":             2,
+		"OCR_OUTPUT_PDFA_1":           3,
+		"OCR_OUTPUT_PDFA_2":           4,
+		"OCR_OUTPUT_PDFA_3":           5,
+	}
+)
+
+func (x OcrOutputType) Enum() *OcrOutputType {
+	p := new(OcrOutputType)
+	*p = x
+	return p
+}
+
+func (x OcrOutputType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OcrOutputType) Descriptor() protoreflect.EnumDescriptor {
+	return file_thumbnail_proto_enumTypes[7].Descriptor()
+}
+
+func (OcrOutputType) Type() protoreflect.EnumType {
+	return &file_thumbnail_proto_enumTypes[7]
+}
+
+func (x OcrOutputType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OcrOutputType.Descriptor instead.
+func (OcrOutputType) EnumDescriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{7}
+}
+
 // Request message for thumbnail generation.
 //
 // The file_content must be a base64-encoded file (image, video, or PDF).
 // Optional max_width and max_height can be provided to resize the thumbnail
 // (values of 0 mean no resizing constraints).
+// To get several sizes from a single upload, list them in sizes instead; the
+// file is then decoded once and one rendition per size is returned.
+// output_format, quality and png_compression control how the thumbnail is
+// encoded (a quality of 0 uses the encoder default).
+// fit_mode decides how the thumbnail is fitted when both a maximum width and
+// a maximum height are given. For COVER and SMART a focal_point can be given
+// to crop around a known subject instead.
+// For PDFs, page selects the rendered page and contact_sheet renders a range
+// of pages into a single grid image.
+// For videos, video_frame selects the position the thumbnail is taken from
+// and storyboard switches to generating scrubbing preview sprites.
+// animated_preview turns a video into a short looping GIF instead.
+// Animated GIF images keep their animation when the thumbnail is a GIF too,
+// unless first_frame_only is set or the frames hold more than 64 megapixels
+// in total.
+// JPEG images are rotated upright by their EXIF orientation before resizing
+// unless disable_auto_orient is set.
+// PDFs that need a password to open fail with PERMISSION_DENIED (HTTP 403)
+// if password is missing or wrong.
 type ThumbnailRequest struct {
+	state             protoimpl.MessageState  `protogen:"open.v1"`
+	FileContent       []byte                  `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`                                                 // Base64-encoded bytes of the file to process.
+	FileType          FileType                `protobuf:"varint,2,opt,name=file_type,json=fileType,proto3,enum=thumbnail_service.FileType" json:"file_type,omitempty"`                         // Specifies the type of the file.
+	MaxWidth          int32                   `protobuf:"varint,3,opt,name=max_width,json=maxWidth,proto3" json:"max_width,omitempty"`                                                         // Maximum width of the generated thumbnail; 0 means no limit.
+	MaxHeight         int32                   `protobuf:"varint,4,opt,name=max_height,json=maxHeight,proto3" json:"max_height,omitempty"`                                                      // Maximum height of the generated thumbnail; 0 means no limit.
+	Sizes             []*ThumbnailSize        `protobuf:"bytes,5,rep,name=sizes,proto3" json:"sizes,omitempty"`                                                                                // Target sizes of the renditions; overrides max_width and max_height when set.
+	OutputFormat      OutputFormat            `protobuf:"varint,6,opt,name=output_format,json=outputFormat,proto3,enum=thumbnail_service.OutputFormat" json:"output_format,omitempty"`         // Image format of the generated thumbnail.
+	Quality           int32                   `protobuf:"varint,7,opt,name=quality,proto3" json:"quality,omitempty"`                                                                           // JPEG and WebP quality from 1 to 100; 0 means the encoder default.
+	PngCompression    PngCompression          `protobuf:"varint,8,opt,name=png_compression,json=pngCompression,proto3,enum=thumbnail_service.PngCompression" json:"png_compression,omitempty"` // Compression level used for PNG thumbnails.
+	FitMode           FitMode                 `protobuf:"varint,9,opt,name=fit_mode,json=fitMode,proto3,enum=thumbnail_service.FitMode" json:"fit_mode,omitempty"`                             // How the thumbnail is fitted into max_width and max_height.
+	BackgroundColor   string                  `protobuf:"bytes,10,opt,name=background_color,json=backgroundColor,proto3" json:"background_color,omitempty"`                                    // Padding color for FitMode PAD as #RRGGBB or #RRGGBBAA; defaults to black.
+	FocalPoint        *FocalPoint             `protobuf:"bytes,11,opt,name=focal_point,json=focalPoint,proto3" json:"focal_point,omitempty"`                                                   // Point the crop of COVER and SMART is centered on.
+	Page              int32                   `protobuf:"varint,12,opt,name=page,proto3" json:"page,omitempty"`                                                                                // 1-based PDF page to render; 0 means the first page. Pages past the end are rejected.
+	ContactSheet      *ContactSheetOptions    `protobuf:"bytes,13,opt,name=contact_sheet,json=contactSheet,proto3" json:"contact_sheet,omitempty"`                                             // Renders several PDF pages, starting at page, into one grid image when set.
+	VideoFrame        *VideoFrameSelection    `protobuf:"bytes,14,opt,name=video_frame,json=videoFrame,proto3" json:"video_frame,omitempty"`                                                   // Frame a video thumbnail is taken from; unset picks a representative frame from the start.
+	Storyboard        *StoryboardOptions      `protobuf:"bytes,15,opt,name=storyboard,proto3" json:"storyboard,omitempty"`                                                                     // Generates a storyboard of the video instead of a thumbnail when set.
+	AnimatedPreview   *AnimatedPreviewOptions `protobuf:"bytes,16,opt,name=animated_preview,json=animatedPreview,proto3" json:"animated_preview,omitempty"`                                    // Generates a looping GIF preview of the video instead of a thumbnail when set.
+	FirstFrameOnly    bool                    `protobuf:"varint,17,opt,name=first_frame_only,json=firstFrameOnly,proto3" json:"first_frame_only,omitempty"`                                    // Flattens animated GIF images to their first frame instead of resizing every frame.
+	DisableAutoOrient bool                    `protobuf:"varint,18,opt,name=disable_auto_orient,json=disableAutoOrient,proto3" json:"disable_auto_orient,omitempty"`                           // Keeps JPEG images as stored instead of rotating them by their EXIF orientation.
+	Password          string                  `protobuf:"bytes,19,opt,name=password,proto3" json:"password,omitempty"`                                                                         // Password of an encrypted PDF; either the user or the owner password.
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ThumbnailRequest) Reset() {
+	*x = ThumbnailRequest{}
+	mi := &file_thumbnail_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThumbnailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThumbnailRequest) ProtoMessage() {}
+
+func (x *ThumbnailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThumbnailRequest.ProtoReflect.Descriptor instead.
+func (*ThumbnailRequest) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ThumbnailRequest) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *ThumbnailRequest) GetFileType() FileType {
+	if x != nil {
+		return x.FileType
+	}
+	return FileType_FILE_TYPE_UNSPECIFIED
+}
+
+func (x *ThumbnailRequest) GetMaxWidth() int32 {
+	if x != nil {
+		return x.MaxWidth
+	}
+	return 0
+}
+
+func (x *ThumbnailRequest) GetMaxHeight() int32 {
+	if x != nil {
+		return x.MaxHeight
+	}
+	return 0
+}
+
+func (x *ThumbnailRequest) GetSizes() []*ThumbnailSize {
+	if x != nil {
+		return x.Sizes
+	}
+	return nil
+}
+
+func (x *ThumbnailRequest) GetOutputFormat() OutputFormat {
+	if x != nil {
+		return x.OutputFormat
+	}
+	return OutputFormat_OUTPUT_FORMAT_UNSPECIFIED
+}
+
+func (x *ThumbnailRequest) GetQuality() int32 {
+	if x != nil {
+		return x.Quality
+	}
+	return 0
+}
+
+func (x *ThumbnailRequest) GetPngCompression() PngCompression {
+	if x != nil {
+		return x.PngCompression
+	}
+	return PngCompression_PNG_COMPRESSION_DEFAULT
+}
+
+func (x *ThumbnailRequest) GetFitMode() FitMode {
+	if x != nil {
+		return x.FitMode
+	}
+	return FitMode_FIT_MODE_UNSPECIFIED
+}
+
+func (x *ThumbnailRequest) GetBackgroundColor() string {
+	if x != nil {
+		return x.BackgroundColor
+	}
+	return ""
+}
+
+func (x *ThumbnailRequest) GetFocalPoint() *FocalPoint {
+	if x != nil {
+		return x.FocalPoint
+	}
+	return nil
+}
+
+func (x *ThumbnailRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ThumbnailRequest) GetContactSheet() *ContactSheetOptions {
+	if x != nil {
+		return x.ContactSheet
+	}
+	return nil
+}
+
+func (x *ThumbnailRequest) GetVideoFrame() *VideoFrameSelection {
+	if x != nil {
+		return x.VideoFrame
+	}
+	return nil
+}
+
+func (x *ThumbnailRequest) GetStoryboard() *StoryboardOptions {
+	if x != nil {
+		return x.Storyboard
+	}
+	return nil
+}
+
+func (x *ThumbnailRequest) GetAnimatedPreview() *AnimatedPreviewOptions {
+	if x != nil {
+		return x.AnimatedPreview
+	}
+	return nil
+}
+
+func (x *ThumbnailRequest) GetFirstFrameOnly() bool {
+	if x != nil {
+		return x.FirstFrameOnly
+	}
+	return false
+}
+
+func (x *ThumbnailRequest) GetDisableAutoOrient() bool {
+	if x != nil {
+		return x.DisableAutoOrient
+	}
+	return false
+}
+
+func (x *ThumbnailRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// Target size of a single thumbnail rendition.
+type ThumbnailSize struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxWidth      int32                  `protobuf:"varint,1,opt,name=max_width,json=maxWidth,proto3" json:"max_width,omitempty"`    // Maximum width of the rendition; 0 means no limit.
+	MaxHeight     int32                  `protobuf:"varint,2,opt,name=max_height,json=maxHeight,proto3" json:"max_height,omitempty"` // Maximum height of the rendition; 0 means no limit.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThumbnailSize) Reset() {
+	*x = ThumbnailSize{}
+	mi := &file_thumbnail_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThumbnailSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThumbnailSize) ProtoMessage() {}
+
+func (x *ThumbnailSize) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThumbnailSize.ProtoReflect.Descriptor instead.
+func (*ThumbnailSize) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ThumbnailSize) GetMaxWidth() int32 {
+	if x != nil {
+		return x.MaxWidth
+	}
+	return 0
+}
+
+func (x *ThumbnailSize) GetMaxHeight() int32 {
+	if x != nil {
+		return x.MaxHeight
+	}
+	return 0
+}
+
+// Options for a contact sheet of several PDF pages.
+//
+// The pages are rendered as tiles of equal size and laid out left to right,
+// top to bottom. Empty space is filled with the background_color of the
+// request, or white if none is given.
+type ContactSheetOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LastPage      int32                  `protobuf:"varint,1,opt,name=last_page,json=lastPage,proto3" json:"last_page,omitempty"` // Last page to include; 0 includes up to 9 pages. Capped at the page count, a sheet spans at most 100 pages.
+	Columns       int32                  `protobuf:"varint,2,opt,name=columns,proto3" json:"columns,omitempty"`                   // Number of columns of the grid; 0 picks a roughly square grid.
+	TileSize      int32                  `protobuf:"varint,3,opt,name=tile_size,json=tileSize,proto3" json:"tile_size,omitempty"` // Longer side of each tile in pixels; 0 means 300.
+	Spacing       int32                  `protobuf:"varint,4,opt,name=spacing,proto3" json:"spacing,omitempty"`                   // Gap between tiles in pixels.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContactSheetOptions) Reset() {
+	*x = ContactSheetOptions{}
+	mi := &file_thumbnail_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContactSheetOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContactSheetOptions) ProtoMessage() {}
+
+func (x *ContactSheetOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContactSheetOptions.ProtoReflect.Descriptor instead.
+func (*ContactSheetOptions) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ContactSheetOptions) GetLastPage() int32 {
+	if x != nil {
+		return x.LastPage
+	}
+	return 0
+}
+
+func (x *ContactSheetOptions) GetColumns() int32 {
+	if x != nil {
+		return x.Columns
+	}
+	return 0
+}
+
+func (x *ContactSheetOptions) GetTileSize() int32 {
+	if x != nil {
+		return x.TileSize
+	}
+	return 0
+}
+
+func (x *ContactSheetOptions) GetSpacing() int32 {
+	if x != nil {
+		return x.Spacing
+	}
+	return 0
+}
+
+// Selects the frame of a video a thumbnail is taken from.
+//
+// Positions are reached by seeking before decoding, so frames late in long
+// videos are found without decoding everything before them.
+type VideoFrameSelection struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Selection:
+	//
+	//	*VideoFrameSelection_Timestamp
+	//	*VideoFrameSelection_Percentage
+	//	*VideoFrameSelection_BestInWindow
+	Selection     isVideoFrameSelection_Selection `protobuf_oneof:"selection"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VideoFrameSelection) Reset() {
+	*x = VideoFrameSelection{}
+	mi := &file_thumbnail_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VideoFrameSelection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoFrameSelection) ProtoMessage() {}
+
+func (x *VideoFrameSelection) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoFrameSelection.ProtoReflect.Descriptor instead.
+func (*VideoFrameSelection) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VideoFrameSelection) GetSelection() isVideoFrameSelection_Selection {
+	if x != nil {
+		return x.Selection
+	}
+	return nil
+}
+
+func (x *VideoFrameSelection) GetTimestamp() float64 {
+	if x != nil {
+		if x, ok := x.Selection.(*VideoFrameSelection_Timestamp); ok {
+			return x.Timestamp
+		}
+	}
+	return 0
+}
+
+func (x *VideoFrameSelection) GetPercentage() float64 {
+	if x != nil {
+		if x, ok := x.Selection.(*VideoFrameSelection_Percentage); ok {
+			return x.Percentage
+		}
+	}
+	return 0
+}
+
+func (x *VideoFrameSelection) GetBestInWindow() *FrameWindow {
+	if x != nil {
+		if x, ok := x.Selection.(*VideoFrameSelection_BestInWindow); ok {
+			return x.BestInWindow
+		}
+	}
+	return nil
+}
+
+type isVideoFrameSelection_Selection interface {
+	isVideoFrameSelection_Selection()
+}
+
+type VideoFrameSelection_Timestamp struct {
+	Timestamp float64 `protobuf:"fixed64,1,opt,name=timestamp,proto3,oneof"` // Absolute position of the frame in seconds.
+}
+
+type VideoFrameSelection_Percentage struct {
+	Percentage float64 `protobuf:"fixed64,2,opt,name=percentage,proto3,oneof"` // Position of the frame as percentage (0-100) of the duration.
+}
+
+type VideoFrameSelection_BestInWindow struct {
+	BestInWindow *FrameWindow `protobuf:"bytes,3,opt,name=best_in_window,json=bestInWindow,proto3,oneof"` // Picks the most representative frame within the window.
+}
+
+func (*VideoFrameSelection_Timestamp) isVideoFrameSelection_Selection() {}
+
+func (*VideoFrameSelection_Percentage) isVideoFrameSelection_Selection() {}
+
+func (*VideoFrameSelection_BestInWindow) isVideoFrameSelection_Selection() {}
+
+// Time window of a video.
+type FrameWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         float64                `protobuf:"fixed64,1,opt,name=start,proto3" json:"start,omitempty"`       // Start of the window in seconds.
+	Duration      float64                `protobuf:"fixed64,2,opt,name=duration,proto3" json:"duration,omitempty"` // Length of the window in seconds; 0 means 10 seconds.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FrameWindow) Reset() {
+	*x = FrameWindow{}
+	mi := &file_thumbnail_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameWindow) ProtoMessage() {}
+
+func (x *FrameWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameWindow.ProtoReflect.Descriptor instead.
+func (*FrameWindow) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FrameWindow) GetStart() float64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *FrameWindow) GetDuration() float64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+// Options for a video storyboard.
+//
+// Frames are sampled every interval seconds, scaled to tiles and packed into
+// sprite images of columns x rows tiles. Output format and quality follow the
+// thumbnail request.
+type StoryboardOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interval      float64                `protobuf:"fixed64,1,opt,name=interval,proto3" json:"interval,omitempty"`                   // Seconds between two sampled frames; 0 means 10 seconds.
+	TileWidth     int32                  `protobuf:"varint,2,opt,name=tile_width,json=tileWidth,proto3" json:"tile_width,omitempty"` // Width of a tile in pixels; 0 means 160. The height follows the aspect ratio.
+	Columns       int32                  `protobuf:"varint,3,opt,name=columns,proto3" json:"columns,omitempty"`                      // Tiles per sprite row; 0 means 10.
+	Rows          int32                  `protobuf:"varint,4,opt,name=rows,proto3" json:"rows,omitempty"`                            // Tile rows per sprite; 0 means 10. Further frames continue in a new sprite.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoryboardOptions) Reset() {
+	*x = StoryboardOptions{}
+	mi := &file_thumbnail_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoryboardOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoryboardOptions) ProtoMessage() {}
+
+func (x *StoryboardOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoryboardOptions.ProtoReflect.Descriptor instead.
+func (*StoryboardOptions) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StoryboardOptions) GetInterval() float64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *StoryboardOptions) GetTileWidth() int32 {
+	if x != nil {
+		return x.TileWidth
+	}
+	return 0
+}
+
+func (x *StoryboardOptions) GetColumns() int32 {
+	if x != nil {
+		return x.Columns
+	}
+	return 0
+}
+
+func (x *StoryboardOptions) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+// A single sprite image of a storyboard.
+type StoryboardSprite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // File name the WebVTT index refers to this sprite by.
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`    // Width of the sprite in pixels.
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`  // Height of the sprite in pixels.
+	Content       []byte                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"` // Base64-encoded bytes of the sprite image.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoryboardSprite) Reset() {
+	*x = StoryboardSprite{}
+	mi := &file_thumbnail_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoryboardSprite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoryboardSprite) ProtoMessage() {}
+
+func (x *StoryboardSprite) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoryboardSprite.ProtoReflect.Descriptor instead.
+func (*StoryboardSprite) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StoryboardSprite) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StoryboardSprite) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *StoryboardSprite) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *StoryboardSprite) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// Generated storyboard of a video.
+//
+// The WebVTT index maps every sampled time range to a region of a sprite,
+// using media fragments like storyboard-0.jpg#xywh=0,0,160,90.
+type Storyboard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sprites       []*StoryboardSprite    `protobuf:"bytes,1,rep,name=sprites,proto3" json:"sprites,omitempty"` // Sprite images in playback order.
+	Vtt           string                 `protobuf:"bytes,2,opt,name=vtt,proto3" json:"vtt,omitempty"`         // WebVTT index of the sprites.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Storyboard) Reset() {
+	*x = Storyboard{}
+	mi := &file_thumbnail_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Storyboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Storyboard) ProtoMessage() {}
+
+func (x *Storyboard) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Storyboard.ProtoReflect.Descriptor instead.
+func (*Storyboard) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Storyboard) GetSprites() []*StoryboardSprite {
+	if x != nil {
+		return x.Sprites
+	}
+	return nil
+}
+
+func (x *Storyboard) GetVtt() string {
+	if x != nil {
+		return x.Vtt
+	}
+	return ""
+}
+
+// Options for an animated GIF preview of a video.
+//
+// Frames are sampled evenly across the whole video and fitted into
+// max_width and max_height like a regular thumbnail. All frames share one
+// palette computed from the sampled frames.
+type AnimatedPreviewOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FrameCount    int32                  `protobuf:"varint,1,opt,name=frame_count,json=frameCount,proto3" json:"frame_count,omitempty"` // Number of frames in the preview; 0 means 10.
+	Delay         int32                  `protobuf:"varint,2,opt,name=delay,proto3" json:"delay,omitempty"`                             // Delay between frames in milliseconds; 0 means 500. Stored in steps of 10 ms.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnimatedPreviewOptions) Reset() {
+	*x = AnimatedPreviewOptions{}
+	mi := &file_thumbnail_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnimatedPreviewOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnimatedPreviewOptions) ProtoMessage() {}
+
+func (x *AnimatedPreviewOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnimatedPreviewOptions.ProtoReflect.Descriptor instead.
+func (*AnimatedPreviewOptions) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AnimatedPreviewOptions) GetFrameCount() int32 {
+	if x != nil {
+		return x.FrameCount
+	}
+	return 0
+}
+
+func (x *AnimatedPreviewOptions) GetDelay() int32 {
+	if x != nil {
+		return x.Delay
+	}
+	return 0
+}
+
+// Point of interest in an image, as fractions of its width and height.
+//
+// (0, 0) is the top left and (1, 1) the bottom right corner.
+type FocalPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             float32                `protobuf:"fixed32,1,opt,name=x,proto3" json:"x,omitempty"` // Horizontal position from 0 to 1.
+	Y             float32                `protobuf:"fixed32,2,opt,name=y,proto3" json:"y,omitempty"` // Vertical position from 0 to 1.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FocalPoint) Reset() {
+	*x = FocalPoint{}
+	mi := &file_thumbnail_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FocalPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FocalPoint) ProtoMessage() {}
+
+func (x *FocalPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FocalPoint.ProtoReflect.Descriptor instead.
+func (*FocalPoint) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FocalPoint) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *FocalPoint) GetY() float32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// Region of the source image a thumbnail was cropped from.
+//
+// Coordinates are pixels of the decoded source, i.e. the uploaded image after
+// EXIF auto orientation, the extracted video frame or the rendered PDF page.
+type CropRect struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`           // Left edge of the crop.
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`           // Top edge of the crop.
+	Width         int32                  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`   // Width of the crop.
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"` // Height of the crop.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CropRect) Reset() {
+	*x = CropRect{}
+	mi := &file_thumbnail_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CropRect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CropRect) ProtoMessage() {}
+
+func (x *CropRect) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CropRect.ProtoReflect.Descriptor instead.
+func (*CropRect) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CropRect) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *CropRect) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *CropRect) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *CropRect) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// A single generated thumbnail of a requested size.
+type ThumbnailRendition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Width         int32                  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`    // Actual width of the rendition in pixels.
+	Height        int32                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`  // Actual height of the rendition in pixels.
+	Content       []byte                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"` // Base64-encoded bytes of the rendition image.
+	Crop          *CropRect              `protobuf:"bytes,4,opt,name=crop,proto3" json:"crop,omitempty"`       // Region the rendition was cropped from; unset if it was not cropped.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThumbnailRendition) Reset() {
+	*x = ThumbnailRendition{}
+	mi := &file_thumbnail_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThumbnailRendition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThumbnailRendition) ProtoMessage() {}
+
+func (x *ThumbnailRendition) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThumbnailRendition.ProtoReflect.Descriptor instead.
+func (*ThumbnailRendition) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ThumbnailRendition) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ThumbnailRendition) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ThumbnailRendition) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ThumbnailRendition) GetCrop() *CropRect {
+	if x != nil {
+		return x.Crop
+	}
+	return nil
+}
+
+// Streaming request message for thumbnail generation.
+//
+// The first message of a stream must set metadata; its file_content may be
+// left empty. All following messages carry consecutive chunks of the file,
+// a stream with a missing or repeated metadata fails with INVALID_ARGUMENT.
+type ThumbnailStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*ThumbnailStreamRequest_Metadata
+	//	*ThumbnailStreamRequest_Chunk
+	Data          isThumbnailStreamRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThumbnailStreamRequest) Reset() {
+	*x = ThumbnailStreamRequest{}
+	mi := &file_thumbnail_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThumbnailStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThumbnailStreamRequest) ProtoMessage() {}
+
+func (x *ThumbnailStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThumbnailStreamRequest.ProtoReflect.Descriptor instead.
+func (*ThumbnailStreamRequest) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ThumbnailStreamRequest) GetData() isThumbnailStreamRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ThumbnailStreamRequest) GetMetadata() *ThumbnailRequest {
+	if x != nil {
+		if x, ok := x.Data.(*ThumbnailStreamRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *ThumbnailStreamRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*ThumbnailStreamRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isThumbnailStreamRequest_Data interface {
+	isThumbnailStreamRequest_Data()
+}
+
+type ThumbnailStreamRequest_Metadata struct {
+	Metadata *ThumbnailRequest `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"` // Thumbnail options, sent once as the first message.
+}
+
+type ThumbnailStreamRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"` // Next chunk of the file to process.
+}
+
+func (*ThumbnailStreamRequest_Metadata) isThumbnailStreamRequest_Data() {}
+
+func (*ThumbnailStreamRequest_Chunk) isThumbnailStreamRequest_Data() {}
+
+// Response message for thumbnail generation.
+//
+// Contains a status message and the generated thumbnail as base64-encoded bytes.
+// If the request listed sizes, thumbnail_content is empty and renditions holds
+// one thumbnail per requested size, in request order.
+// mime_type describes the format of all returned images.
+type ThumbnailResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Message            string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`                                                                              // Status or informational message about the thumbnail generation.
+	ThumbnailContent   []byte                 `protobuf:"bytes,2,opt,name=thumbnail_content,json=thumbnailContent,proto3" json:"thumbnail_content,omitempty"`                                    // Base64-encoded bytes of the generated thumbnail image.
+	Renditions         []*ThumbnailRendition  `protobuf:"bytes,3,rep,name=renditions,proto3" json:"renditions,omitempty"`                                                                        // Generated renditions, one per requested size.
+	MimeType           string                 `protobuf:"bytes,4,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`                                                            // MIME type of the generated images, e.g. image/jpeg.
+	DetectedFileType   FileType               `protobuf:"varint,5,opt,name=detected_file_type,json=detectedFileType,proto3,enum=thumbnail_service.FileType" json:"detected_file_type,omitempty"` // Type the file was processed as; detected from the content if the request left it unspecified.
+	Crop               *CropRect              `protobuf:"bytes,6,opt,name=crop,proto3" json:"crop,omitempty"`                                                                                    // Region thumbnail_content was cropped from; unset if it was not cropped.
+	Storyboard         *Storyboard            `protobuf:"bytes,7,opt,name=storyboard,proto3" json:"storyboard,omitempty"`                                                                        // Generated storyboard if the request asked for one.
+	AppliedOrientation int32                  `protobuf:"varint,8,opt,name=applied_orientation,json=appliedOrientation,proto3" json:"applied_orientation,omitempty"`                             // EXIF orientation (1-8) the image was rotated by; 0 if none was applied.
+	Repaired           bool                   `protobuf:"varint,9,opt,name=repaired,proto3" json:"repaired,omitempty"`                                                                           // Whether the PDF was damaged and had to be repaired before rendering.
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ThumbnailResponse) Reset() {
+	*x = ThumbnailResponse{}
+	mi := &file_thumbnail_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThumbnailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThumbnailResponse) ProtoMessage() {}
+
+func (x *ThumbnailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThumbnailResponse.ProtoReflect.Descriptor instead.
+func (*ThumbnailResponse) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ThumbnailResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ThumbnailResponse) GetThumbnailContent() []byte {
+	if x != nil {
+		return x.ThumbnailContent
+	}
+	return nil
+}
+
+func (x *ThumbnailResponse) GetRenditions() []*ThumbnailRendition {
+	if x != nil {
+		return x.Renditions
+	}
+	return nil
+}
+
+func (x *ThumbnailResponse) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *ThumbnailResponse) GetDetectedFileType() FileType {
+	if x != nil {
+		return x.DetectedFileType
+	}
+	return FileType_FILE_TYPE_UNSPECIFIED
+}
+
+func (x *ThumbnailResponse) GetCrop() *CropRect {
+	if x != nil {
+		return x.Crop
+	}
+	return nil
+}
+
+func (x *ThumbnailResponse) GetStoryboard() *Storyboard {
+	if x != nil {
+		return x.Storyboard
+	}
+	return nil
+}
+
+func (x *ThumbnailResponse) GetAppliedOrientation() int32 {
+	if x != nil {
+		return x.AppliedOrientation
+	}
+	return 0
+}
+
+func (x *ThumbnailResponse) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+// A single file of a batch thumbnail request.
+type ThumbnailBatchItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`           // Client supplied identifier, echoed in the matching result.
+	Request       *ThumbnailRequest      `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"` // Thumbnail request for this file.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThumbnailBatchItem) Reset() {
+	*x = ThumbnailBatchItem{}
+	mi := &file_thumbnail_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThumbnailBatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThumbnailBatchItem) ProtoMessage() {}
+
+func (x *ThumbnailBatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThumbnailBatchItem.ProtoReflect.Descriptor instead.
+func (*ThumbnailBatchItem) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ThumbnailBatchItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ThumbnailBatchItem) GetRequest() *ThumbnailRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+// Request message for batch thumbnail generation.
+type ThumbnailBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ThumbnailBatchItem  `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // Files to generate thumbnails for.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThumbnailBatchRequest) Reset() {
+	*x = ThumbnailBatchRequest{}
+	mi := &file_thumbnail_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThumbnailBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThumbnailBatchRequest) ProtoMessage() {}
+
+func (x *ThumbnailBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThumbnailBatchRequest.ProtoReflect.Descriptor instead.
+func (*ThumbnailBatchRequest) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ThumbnailBatchRequest) GetItems() []*ThumbnailBatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Result for a single item of a batch thumbnail request.
+//
+// On failure success is false, error describes the problem and response is unset.
+type ThumbnailBatchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`             // Identifier of the item this result belongs to.
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`  // Whether the thumbnail was generated.
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`       // Error message if the item failed.
+	Response      *ThumbnailResponse     `protobuf:"bytes,4,opt,name=response,proto3" json:"response,omitempty"` // Generated thumbnail if the item succeeded.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThumbnailBatchResult) Reset() {
+	*x = ThumbnailBatchResult{}
+	mi := &file_thumbnail_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThumbnailBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThumbnailBatchResult) ProtoMessage() {}
+
+func (x *ThumbnailBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThumbnailBatchResult.ProtoReflect.Descriptor instead.
+func (*ThumbnailBatchResult) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ThumbnailBatchResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ThumbnailBatchResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ThumbnailBatchResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ThumbnailBatchResult) GetResponse() *ThumbnailResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// Response message for batch thumbnail generation.
+//
+// Contains one result per requested item, in the same order as the request.
+type ThumbnailBatchResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Message       string                  `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Summary of the batch operation.
+	Results       []*ThumbnailBatchResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"` // Per-item results.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThumbnailBatchResponse) Reset() {
+	*x = ThumbnailBatchResponse{}
+	mi := &file_thumbnail_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThumbnailBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThumbnailBatchResponse) ProtoMessage() {}
+
+func (x *ThumbnailBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThumbnailBatchResponse.ProtoReflect.Descriptor instead.
+func (*ThumbnailBatchResponse) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ThumbnailBatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ThumbnailBatchResponse) GetResults() []*ThumbnailBatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Request message for OCR processing.
+//
+// The file_content must be a base64-encoded PDF or image. Images (JPEG, PNG,
+// TIFF including multi-page TIFF) are converted to a PDF with one page per
+// image before the OCR runs.
+// cleanup_options selects the stages that clean up the extracted text; the
+// deprecated cleanUp flag is only used if cleanup_options is unset.
+// languages must be installed on the server, otherwise the request fails.
+// With detect_language, orientation and script detection runs first and all
+// installed languages written in the detected script are used. It tries up
+// to three pages to OCR; if none shows a script, only languages is used.
+// With a layout_format, the response also carries the position of every word.
+// The layout is read from the text layer of the PDF; words on OCRed pages
+// also carry the confidence tesseract reported for them.
+// PDFs that need a password to open fail with PERMISSION_DENIED (HTTP 403)
+// if password is missing or wrong. The returned PDF is decrypted if the OCR
+// or a repair had to modify it.
+// Invalid combinations of options fail with INVALID_ARGUMENT.
+type OCRFileRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	FileContent []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`                         // Base64-encoded bytes of the file to OCR.
+	FileType    FileType               `protobuf:"varint,2,opt,name=file_type,json=fileType,proto3,enum=thumbnail_service.FileType" json:"file_type,omitempty"` // Type of the file; detected from the content if unspecified.
+	// Deprecated: Marked as deprecated in thumbnail.proto.
+	CleanUp        bool            `protobuf:"varint,3,opt,name=cleanUp,proto3" json:"cleanUp,omitempty"`                                                                   // Deprecated, use cleanup_options; same as enabling remove_separator_lines and collapse_whitespace.
+	Languages      []string        `protobuf:"bytes,4,rep,name=languages,proto3" json:"languages,omitempty"`                                                                // Tesseract language codes to OCR with, e.g. eng and deu; empty uses eng.
+	DetectLanguage bool            `protobuf:"varint,5,opt,name=detect_language,json=detectLanguage,proto3" json:"detect_language,omitempty"`                               // Detects the script of the first pages to OCR and adds the matching installed languages.
+	LayoutFormat   LayoutFormat    `protobuf:"varint,6,opt,name=layout_format,json=layoutFormat,proto3,enum=thumbnail_service.LayoutFormat" json:"layout_format,omitempty"` // Format of the word layout to return alongside the text.
+	Password       string          `protobuf:"bytes,7,opt,name=password,proto3" json:"password,omitempty"`                                                                  // Password of an encrypted PDF; either the user or the owner password.
+	Options        *OcrOptions     `protobuf:"bytes,8,opt,name=options,proto3" json:"options,omitempty"`                                                                    // Preprocessing and output options of the OCR; unset uses the defaults.
+	CleanupOptions *CleanupOptions `protobuf:"bytes,9,opt,name=cleanup_options,json=cleanupOptions,proto3" json:"cleanup_options,omitempty"`                                // Cleanup stages applied to text_content and the page texts; unset leaves the text as extracted.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OCRFileRequest) Reset() {
+	*x = OCRFileRequest{}
+	mi := &file_thumbnail_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OCRFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OCRFileRequest) ProtoMessage() {}
+
+func (x *OCRFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OCRFileRequest.ProtoReflect.Descriptor instead.
+func (*OCRFileRequest) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OCRFileRequest) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *OCRFileRequest) GetFileType() FileType {
+	if x != nil {
+		return x.FileType
+	}
+	return FileType_FILE_TYPE_UNSPECIFIED
+}
+
+// Deprecated: Marked as deprecated in thumbnail.proto.
+func (x *OCRFileRequest) GetCleanUp() bool {
+	if x != nil {
+		return x.CleanUp
+	}
+	return false
+}
+
+func (x *OCRFileRequest) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *OCRFileRequest) GetDetectLanguage() bool {
+	if x != nil {
+		return x.DetectLanguage
+	}
+	return false
+}
+
+func (x *OCRFileRequest) GetLayoutFormat() LayoutFormat {
+	if x != nil {
+		return x.LayoutFormat
+	}
+	return LayoutFormat_LAYOUT_FORMAT_NONE
+}
+
+func (x *OCRFileRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *OCRFileRequest) GetOptions() *OcrOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *OCRFileRequest) GetCleanupOptions() *CleanupOptions {
+	if x != nil {
+		return x.CleanupOptions
+	}
+	return nil
+}
+
+// Stages that clean up the extracted text.
+//
+// The stages run in field order on every page. Running headers and footers
+// are the first and last lines that repeat, with page numbers ignored, on at
+// least half of the pages of a document with three or more pages.
+type CleanupOptions struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	StripControlCharacters bool                   `protobuf:"varint,1,opt,name=strip_control_characters,json=stripControlCharacters,proto3" json:"strip_control_characters,omitempty"` // Removes control and format characters except line breaks and tabs.
+	NormalizeUnicode       bool                   `protobuf:"varint,2,opt,name=normalize_unicode,json=normalizeUnicode,proto3" json:"normalize_unicode,omitempty"`                     // Applies Unicode NFKC normalization, which also expands ligatures.
+	ExpandLigatures        bool                   `protobuf:"varint,3,opt,name=expand_ligatures,json=expandLigatures,proto3" json:"expand_ligatures,omitempty"`                        // Expands typographic ligatures, e.g. U+FB01 to fi.
+	RemoveHeadersFooters   bool                   `protobuf:"varint,4,opt,name=remove_headers_footers,json=removeHeadersFooters,proto3" json:"remove_headers_footers,omitempty"`       // Removes running headers and footers repeated across pages.
+	RemoveSeparatorLines   bool                   `protobuf:"varint,5,opt,name=remove_separator_lines,json=removeSeparatorLines,proto3" json:"remove_separator_lines,omitempty"`       // Removes lines made of one repeated punctuation character, like ----.
+	Dehyphenate            bool                   `protobuf:"varint,6,opt,name=dehyphenate,proto3" json:"dehyphenate,omitempty"`                                                       // Joins words hyphenated across a line break.
+	CollapseWhitespace     bool                   `protobuf:"varint,7,opt,name=collapse_whitespace,json=collapseWhitespace,proto3" json:"collapse_whitespace,omitempty"`               // Collapses runs of whitespace and joins all lines into one.
+	PreserveParagraphs     bool                   `protobuf:"varint,8,opt,name=preserve_paragraphs,json=preserveParagraphs,proto3" json:"preserve_paragraphs,omitempty"`               // With collapse_whitespace, joins lines per paragraph and keeps the blank line between paragraphs.
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CleanupOptions) Reset() {
+	*x = CleanupOptions{}
+	mi := &file_thumbnail_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupOptions) ProtoMessage() {}
+
+func (x *CleanupOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupOptions.ProtoReflect.Descriptor instead.
+func (*CleanupOptions) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CleanupOptions) GetStripControlCharacters() bool {
+	if x != nil {
+		return x.StripControlCharacters
+	}
+	return false
+}
+
+func (x *CleanupOptions) GetNormalizeUnicode() bool {
+	if x != nil {
+		return x.NormalizeUnicode
+	}
+	return false
+}
+
+func (x *CleanupOptions) GetExpandLigatures() bool {
+	if x != nil {
+		return x.ExpandLigatures
+	}
+	return false
+}
+
+func (x *CleanupOptions) GetRemoveHeadersFooters() bool {
+	if x != nil {
+		return x.RemoveHeadersFooters
+	}
+	return false
+}
+
+func (x *CleanupOptions) GetRemoveSeparatorLines() bool {
+	if x != nil {
+		return x.RemoveSeparatorLines
+	}
+	return false
+}
+
+func (x *CleanupOptions) GetDehyphenate() bool {
+	if x != nil {
+		return x.Dehyphenate
+	}
+	return false
+}
+
+func (x *CleanupOptions) GetCollapseWhitespace() bool {
+	if x != nil {
+		return x.CollapseWhitespace
+	}
+	return false
+}
+
+func (x *CleanupOptions) GetPreserveParagraphs() bool {
+	if x != nil {
+		return x.PreserveParagraphs
+	}
+	return false
+}
+
+// Preprocessing and output options of the OCR.
+//
+// deskew and clean_final can't be combined with REDO_OCR, since redoing the
+// OCR must not change the page images. clean and clean_final require unpaper.
+// The options only take effect if any page is OCRed; a born-digital PDF is
+// returned unchanged unless the mode forces the OCR or a PDF/A output type
+// is requested. A PDF/A output whose metadata doesn't claim the requested
+// part fails; the output is not run through a PDF/A validator.
+type OcrOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deskew        bool                   `protobuf:"varint,1,opt,name=deskew,proto3" json:"deskew,omitempty"`                                                                // Straightens crooked pages before the OCR; changes the output.
+	RotatePages   bool                   `protobuf:"varint,2,opt,name=rotate_pages,json=rotatePages,proto3" json:"rotate_pages,omitempty"`                                   // Rotates pages to their upright orientation; changes the output.
+	Clean         bool                   `protobuf:"varint,3,opt,name=clean,proto3" json:"clean,omitempty"`                                                                  // Cleans pages before the OCR without changing the output.
+	CleanFinal    bool                   `protobuf:"varint,4,opt,name=clean_final,json=cleanFinal,proto3" json:"clean_final,omitempty"`                                      // Cleans pages before the OCR and keeps the cleaned pages in the output.
+	Mode          OcrMode                `protobuf:"varint,5,opt,name=mode,proto3,enum=thumbnail_service.OcrMode" json:"mode,omitempty"`                                     // Which pages are OCRed.
+	Optimize      OptimizeLevel          `protobuf:"varint,6,opt,name=optimize,proto3,enum=thumbnail_service.OptimizeLevel" json:"optimize,omitempty"`                       // Optimization of the output PDF.
+	OutputType    OcrOutputType          `protobuf:"varint,7,opt,name=output_type,json=outputType,proto3,enum=thumbnail_service.OcrOutputType" json:"output_type,omitempty"` // Type of the output PDF.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OcrOptions) Reset() {
+	*x = OcrOptions{}
+	mi := &file_thumbnail_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OcrOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OcrOptions) ProtoMessage() {}
+
+func (x *OcrOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OcrOptions.ProtoReflect.Descriptor instead.
+func (*OcrOptions) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *OcrOptions) GetDeskew() bool {
+	if x != nil {
+		return x.Deskew
+	}
+	return false
+}
+
+func (x *OcrOptions) GetRotatePages() bool {
+	if x != nil {
+		return x.RotatePages
+	}
+	return false
+}
+
+func (x *OcrOptions) GetClean() bool {
+	if x != nil {
+		return x.Clean
+	}
+	return false
+}
+
+func (x *OcrOptions) GetCleanFinal() bool {
+	if x != nil {
+		return x.CleanFinal
+	}
+	return false
+}
+
+func (x *OcrOptions) GetMode() OcrMode {
+	if x != nil {
+		return x.Mode
+	}
+	return OcrMode_OCR_MODE_AUTO
+}
+
+func (x *OcrOptions) GetOptimize() OptimizeLevel {
+	if x != nil {
+		return x.Optimize
+	}
+	return OptimizeLevel_OPTIMIZE_DEFAULT
+}
+
+func (x *OcrOptions) GetOutputType() OcrOutputType {
+	if x != nil {
+		return x.OutputType
+	}
+	return OcrOutputType_OCR_OUTPUT_TYPE_UNSPECIFIED
+}
+
+// Response message for OCR processing.
+//
+// Contains a status message, the OCRed file content as bytes, and
+// the extracted text content as a string. The OCRed file is always a
+// searchable PDF, also when an image was uploaded.
+// layout_content and layout are only set if the request asked for a
+// layout_format.
+// By default only pages with fewer than 20 non-space characters of text are
+// OCRed, so scanned pages with a stamped page number or Bates ID are OCRed
+// while born-digital pages of mixed documents keep their original text;
+// ocr_pages lists the OCRed pages.
+type OCRFileResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Message          string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`                                                                              // Status message about the OCR operation.
+	OcrContent       []byte                 `protobuf:"bytes,2,opt,name=ocr_content,json=ocrContent,proto3" json:"ocr_content,omitempty"`                                                      // Base64-encoded bytes of the OCR processed PDF.
+	TextContent      string                 `protobuf:"bytes,3,opt,name=text_content,json=textContent,proto3" json:"text_content,omitempty"`                                                   // Extracted text content from the file.
+	DetectedFileType FileType               `protobuf:"varint,4,opt,name=detected_file_type,json=detectedFileType,proto3,enum=thumbnail_service.FileType" json:"detected_file_type,omitempty"` // Type the file was processed as; detected from the content if the request left it unspecified.
+	Languages        []string               `protobuf:"bytes,5,rep,name=languages,proto3" json:"languages,omitempty"`                                                                          // Languages the OCR ran with, eng if none were requested or detected; empty if the file needed no OCR.
+	LayoutContent    string                 `protobuf:"bytes,6,opt,name=layout_content,json=layoutContent,proto3" json:"layout_content,omitempty"`                                             // hOCR or ALTO XML document with the word layout.
+	Layout           []*LayoutPage          `protobuf:"bytes,7,rep,name=layout,proto3" json:"layout,omitempty"`                                                                                // Word layout of every page.
+	Pages            []*OCRPage             `protobuf:"bytes,8,rep,name=pages,proto3" json:"pages,omitempty"`                                                                                  // Text of every page in page order.
+	Repaired         bool                   `protobuf:"varint,9,opt,name=repaired,proto3" json:"repaired,omitempty"`                                                                           // Whether the PDF was damaged and had to be repaired; ocr_content is the repaired file.
+	OcrPages         []int32                `protobuf:"varint,10,rep,packed,name=ocr_pages,json=ocrPages,proto3" json:"ocr_pages,omitempty"`                                                   // 1-based numbers of the pages that lacked a text layer and were OCRed.
+	ClaimedPdfaLevel string                 `protobuf:"bytes,11,opt,name=claimed_pdfa_level,json=claimedPdfaLevel,proto3" json:"claimed_pdfa_level,omitempty"`                                 // PDF/A part and conformance the XMP metadata of ocr_content claims, e.g. PDF/A-2B; not validated, empty if there is no claim.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OCRFileResponse) Reset() {
+	*x = OCRFileResponse{}
+	mi := &file_thumbnail_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OCRFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OCRFileResponse) ProtoMessage() {}
+
+func (x *OCRFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OCRFileResponse.ProtoReflect.Descriptor instead.
+func (*OCRFileResponse) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *OCRFileResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *OCRFileResponse) GetOcrContent() []byte {
+	if x != nil {
+		return x.OcrContent
+	}
+	return nil
+}
+
+func (x *OCRFileResponse) GetTextContent() string {
+	if x != nil {
+		return x.TextContent
+	}
+	return ""
+}
+
+func (x *OCRFileResponse) GetDetectedFileType() FileType {
+	if x != nil {
+		return x.DetectedFileType
+	}
+	return FileType_FILE_TYPE_UNSPECIFIED
+}
+
+func (x *OCRFileResponse) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *OCRFileResponse) GetLayoutContent() string {
+	if x != nil {
+		return x.LayoutContent
+	}
+	return ""
+}
+
+func (x *OCRFileResponse) GetLayout() []*LayoutPage {
+	if x != nil {
+		return x.Layout
+	}
+	return nil
+}
+
+func (x *OCRFileResponse) GetPages() []*OCRPage {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+func (x *OCRFileResponse) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+func (x *OCRFileResponse) GetOcrPages() []int32 {
+	if x != nil {
+		return x.OcrPages
+	}
+	return nil
+}
+
+func (x *OCRFileResponse) GetClaimedPdfaLevel() string {
+	if x != nil {
+		return x.ClaimedPdfaLevel
+	}
+	return ""
+}
+
+// Streaming response message for OCR processing.
+//
+// Every message carries exactly one of the fields. The messages are sent in
+// the order text_content chunks, pages, layout_content chunks, layout pages,
+// ocr_content chunks; the summary is always sent last.
+type OCRFileChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*OCRFileChunk_TextContent
+	//	*OCRFileChunk_OcrContent
+	//	*OCRFileChunk_Summary
+	//	*OCRFileChunk_Page
+	//	*OCRFileChunk_LayoutContent
+	//	*OCRFileChunk_Layout
+	Data          isOCRFileChunk_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OCRFileChunk) Reset() {
+	*x = OCRFileChunk{}
+	mi := &file_thumbnail_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OCRFileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OCRFileChunk) ProtoMessage() {}
+
+func (x *OCRFileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OCRFileChunk.ProtoReflect.Descriptor instead.
+func (*OCRFileChunk) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *OCRFileChunk) GetData() isOCRFileChunk_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *OCRFileChunk) GetTextContent() string {
+	if x != nil {
+		if x, ok := x.Data.(*OCRFileChunk_TextContent); ok {
+			return x.TextContent
+		}
+	}
+	return ""
+}
+
+func (x *OCRFileChunk) GetOcrContent() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*OCRFileChunk_OcrContent); ok {
+			return x.OcrContent
+		}
+	}
+	return nil
+}
+
+func (x *OCRFileChunk) GetSummary() *OCRFileResponse {
+	if x != nil {
+		if x, ok := x.Data.(*OCRFileChunk_Summary); ok {
+			return x.Summary
+		}
+	}
+	return nil
+}
+
+func (x *OCRFileChunk) GetPage() *OCRPage {
+	if x != nil {
+		if x, ok := x.Data.(*OCRFileChunk_Page); ok {
+			return x.Page
+		}
+	}
+	return nil
+}
+
+func (x *OCRFileChunk) GetLayoutContent() string {
+	if x != nil {
+		if x, ok := x.Data.(*OCRFileChunk_LayoutContent); ok {
+			return x.LayoutContent
+		}
+	}
+	return ""
+}
+
+func (x *OCRFileChunk) GetLayout() *LayoutPage {
+	if x != nil {
+		if x, ok := x.Data.(*OCRFileChunk_Layout); ok {
+			return x.Layout
+		}
+	}
+	return nil
+}
+
+type isOCRFileChunk_Data interface {
+	isOCRFileChunk_Data()
+}
+
+type OCRFileChunk_TextContent struct {
+	TextContent string `protobuf:"bytes,1,opt,name=text_content,json=textContent,proto3,oneof"` // Next chunk of the extracted text content.
+}
+
+type OCRFileChunk_OcrContent struct {
+	OcrContent []byte `protobuf:"bytes,2,opt,name=ocr_content,json=ocrContent,proto3,oneof"` // Next chunk of the OCR processed file.
+}
+
+type OCRFileChunk_Summary struct {
+	Summary *OCRFileResponse `protobuf:"bytes,3,opt,name=summary,proto3,oneof"` // Final status; carries message, detected_file_type, languages, repaired, ocr_pages and claimed_pdfa_level, the other fields are left empty.
+}
+
+type OCRFileChunk_Page struct {
+	Page *OCRPage `protobuf:"bytes,4,opt,name=page,proto3,oneof"` // Text of the next page.
+}
+
+type OCRFileChunk_LayoutContent struct {
+	LayoutContent string `protobuf:"bytes,5,opt,name=layout_content,json=layoutContent,proto3,oneof"` // Next chunk of the hOCR or ALTO XML document.
+}
+
+type OCRFileChunk_Layout struct {
+	Layout *LayoutPage `protobuf:"bytes,6,opt,name=layout,proto3,oneof"` // Word layout of the next page.
+}
+
+func (*OCRFileChunk_TextContent) isOCRFileChunk_Data() {}
+
+func (*OCRFileChunk_OcrContent) isOCRFileChunk_Data() {}
+
+func (*OCRFileChunk_Summary) isOCRFileChunk_Data() {}
+
+func (*OCRFileChunk_Page) isOCRFileChunk_Data() {}
+
+func (*OCRFileChunk_LayoutContent) isOCRFileChunk_Data() {}
+
+func (*OCRFileChunk_Layout) isOCRFileChunk_Data() {}
+
+// Text extracted from a single page.
+type OCRPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageNumber    int32                  `protobuf:"varint,1,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"` // 1-based page number.
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`                                // Text of the page; cleaned up like text_content.
+	Ocred         bool                   `protobuf:"varint,3,opt,name=ocred,proto3" json:"ocred,omitempty"`                             // Whether the text comes from OCR rather than the native text layer.
+	CharCount     int32                  `protobuf:"varint,4,opt,name=char_count,json=charCount,proto3" json:"char_count,omitempty"`    // Number of characters in text.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OCRPage) Reset() {
+	*x = OCRPage{}
+	mi := &file_thumbnail_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OCRPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OCRPage) ProtoMessage() {}
+
+func (x *OCRPage) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OCRPage.ProtoReflect.Descriptor instead.
+func (*OCRPage) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *OCRPage) GetPageNumber() int32 {
+	if x != nil {
+		return x.PageNumber
+	}
+	return 0
+}
+
+func (x *OCRPage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *OCRPage) GetOcred() bool {
+	if x != nil {
+		return x.Ocred
+	}
+	return false
+}
+
+func (x *OCRPage) GetCharCount() int32 {
+	if x != nil {
+		return x.CharCount
+	}
+	return 0
+}
+
+// Word layout of a single page.
+//
+// All coordinates are PDF points (1/72 inch) measured from the top left
+// corner of the page.
+type LayoutPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageNumber    int32                  `protobuf:"varint,1,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"` // 1-based page number.
+	Width         float64                `protobuf:"fixed64,2,opt,name=width,proto3" json:"width,omitempty"`                            // Page width in points.
+	Height        float64                `protobuf:"fixed64,3,opt,name=height,proto3" json:"height,omitempty"`                          // Page height in points.
+	Lines         []*LayoutLine          `protobuf:"bytes,4,rep,name=lines,proto3" json:"lines,omitempty"`                              // Lines of text in reading order.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LayoutPage) Reset() {
+	*x = LayoutPage{}
+	mi := &file_thumbnail_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LayoutPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayoutPage) ProtoMessage() {}
+
+func (x *LayoutPage) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayoutPage.ProtoReflect.Descriptor instead.
+func (*LayoutPage) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LayoutPage) GetPageNumber() int32 {
+	if x != nil {
+		return x.PageNumber
+	}
+	return 0
+}
+
+func (x *LayoutPage) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *LayoutPage) GetHeight() float64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *LayoutPage) GetLines() []*LayoutLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// A line of text on a page.
+type LayoutLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bbox          *BoundingBox           `protobuf:"bytes,1,opt,name=bbox,proto3" json:"bbox,omitempty"`   // Area covered by the line.
+	Words         []*LayoutWord          `protobuf:"bytes,2,rep,name=words,proto3" json:"words,omitempty"` // Words of the line from left to right.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LayoutLine) Reset() {
+	*x = LayoutLine{}
+	mi := &file_thumbnail_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LayoutLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayoutLine) ProtoMessage() {}
+
+func (x *LayoutLine) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayoutLine.ProtoReflect.Descriptor instead.
+func (*LayoutLine) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *LayoutLine) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+func (x *LayoutLine) GetWords() []*LayoutWord {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+// A single word on a page.
+type LayoutWord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`               // Text of the word.
+	Bbox          *BoundingBox           `protobuf:"bytes,2,opt,name=bbox,proto3" json:"bbox,omitempty"`               // Area covered by the word.
+	Confidence    float32                `protobuf:"fixed32,3,opt,name=confidence,proto3" json:"confidence,omitempty"` // OCR confidence from 0 to 100; 0 on pages that weren't OCRed, their native text carries none.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LayoutWord) Reset() {
+	*x = LayoutWord{}
+	mi := &file_thumbnail_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LayoutWord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayoutWord) ProtoMessage() {}
+
+func (x *LayoutWord) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayoutWord.ProtoReflect.Descriptor instead.
+func (*LayoutWord) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LayoutWord) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *LayoutWord) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+func (x *LayoutWord) GetConfidence() float32 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+// Axis aligned rectangle in PDF points from the top left corner of the page.
+type BoundingBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	XMin          float64                `protobuf:"fixed64,1,opt,name=x_min,json=xMin,proto3" json:"x_min,omitempty"` // Left edge.
+	YMin          float64                `protobuf:"fixed64,2,opt,name=y_min,json=yMin,proto3" json:"y_min,omitempty"` // Top edge.
+	XMax          float64                `protobuf:"fixed64,3,opt,name=x_max,json=xMax,proto3" json:"x_max,omitempty"` // Right edge.
+	YMax          float64                `protobuf:"fixed64,4,opt,name=y_max,json=yMax,proto3" json:"y_max,omitempty"` // Bottom edge.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
+	mi := &file_thumbnail_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundingBox) ProtoMessage() {}
+
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BoundingBox) GetXMin() float64 {
+	if x != nil {
+		return x.XMin
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetYMin() float64 {
+	if x != nil {
+		return x.YMin
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetXMax() float64 {
+	if x != nil {
+		return x.XMax
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetYMax() float64 {
+	if x != nil {
+		return x.YMax
+	}
+	return 0
+}
+
+// Request message for file metadata.
+//
+// The file_content must be a base64-encoded file (image, video, or PDF).
+type FileInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`                         // Base64-encoded bytes of the file to inspect.
+	FileType      FileType               `protobuf:"varint,2,opt,name=file_type,json=fileType,proto3,enum=thumbnail_service.FileType" json:"file_type,omitempty"` // Type of the file; detected from the content if unspecified.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileInfoRequest) Reset() {
+	*x = FileInfoRequest{}
+	mi := &file_thumbnail_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfoRequest) ProtoMessage() {}
+
+func (x *FileInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfoRequest.ProtoReflect.Descriptor instead.
+func (*FileInfoRequest) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FileInfoRequest) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *FileInfoRequest) GetFileType() FileType {
+	if x != nil {
+		return x.FileType
+	}
+	return FileType_FILE_TYPE_UNSPECIFIED
+}
+
+// Response message for file metadata.
+//
+// Exactly one of image, pdf and video is set, matching detected_file_type.
+type FileInfoResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Message          string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`                                                                              // Status message about the operation.
+	DetectedFileType FileType               `protobuf:"varint,2,opt,name=detected_file_type,json=detectedFileType,proto3,enum=thumbnail_service.FileType" json:"detected_file_type,omitempty"` // Type the file was inspected as.
+	Size             int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`                                                                                   // Size of the file in bytes.
+	// Types that are valid to be assigned to Info:
+	//
+	//	*FileInfoResponse_Image
+	//	*FileInfoResponse_Pdf
+	//	*FileInfoResponse_Video
+	Info          isFileInfoResponse_Info `protobuf_oneof:"info"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileInfoResponse) Reset() {
+	*x = FileInfoResponse{}
+	mi := &file_thumbnail_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfoResponse) ProtoMessage() {}
+
+func (x *FileInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfoResponse.ProtoReflect.Descriptor instead.
+func (*FileInfoResponse) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FileInfoResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *FileInfoResponse) GetDetectedFileType() FileType {
+	if x != nil {
+		return x.DetectedFileType
+	}
+	return FileType_FILE_TYPE_UNSPECIFIED
+}
+
+func (x *FileInfoResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileInfoResponse) GetInfo() isFileInfoResponse_Info {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *FileInfoResponse) GetImage() *ImageInfo {
+	if x != nil {
+		if x, ok := x.Info.(*FileInfoResponse_Image); ok {
+			return x.Image
+		}
+	}
+	return nil
+}
+
+func (x *FileInfoResponse) GetPdf() *PdfInfo {
+	if x != nil {
+		if x, ok := x.Info.(*FileInfoResponse_Pdf); ok {
+			return x.Pdf
+		}
+	}
+	return nil
+}
+
+func (x *FileInfoResponse) GetVideo() *VideoInfo {
+	if x != nil {
+		if x, ok := x.Info.(*FileInfoResponse_Video); ok {
+			return x.Video
+		}
+	}
+	return nil
+}
+
+type isFileInfoResponse_Info interface {
+	isFileInfoResponse_Info()
+}
+
+type FileInfoResponse_Image struct {
+	Image *ImageInfo `protobuf:"bytes,4,opt,name=image,proto3,oneof"` // Metadata of an image.
+}
+
+type FileInfoResponse_Pdf struct {
+	Pdf *PdfInfo `protobuf:"bytes,5,opt,name=pdf,proto3,oneof"` // Metadata of a PDF.
+}
+
+type FileInfoResponse_Video struct {
+	Video *VideoInfo `protobuf:"bytes,6,opt,name=video,proto3,oneof"` // Metadata of a video.
+}
+
+func (*FileInfoResponse_Image) isFileInfoResponse_Info() {}
+
+func (*FileInfoResponse_Pdf) isFileInfoResponse_Info() {}
+
+func (*FileInfoResponse_Video) isFileInfoResponse_Info() {}
+
+// Metadata of an image file.
+type ImageInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`                         // Base64-encoded bytes of the file to process.
-	FileType      FileType               `protobuf:"varint,2,opt,name=file_type,json=fileType,proto3,enum=thumbnail_service.FileType" json:"file_type,omitempty"` // Specifies the type of the file.
-	MaxWidth      int32                  `protobuf:"varint,3,opt,name=max_width,json=maxWidth,proto3" json:"max_width,omitempty"`                                 // Maximum width of the generated thumbnail; 0 means no limit.
-	MaxHeight     int32                  `protobuf:"varint,4,opt,name=max_height,json=maxHeight,proto3" json:"max_height,omitempty"`                              // Maximum height of the generated thumbnail; 0 means no limit.
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`                            // Encoding of the image, e.g. jpeg, png or gif.
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`                             // Width in pixels as stored.
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`                           // Height in pixels as stored.
+	ColorModel    string                 `protobuf:"bytes,4,opt,name=color_model,json=colorModel,proto3" json:"color_model,omitempty"`  // Color model of the decoded image, e.g. YCbCr, RGBA or Paletted.
+	Orientation   int32                  `protobuf:"varint,5,opt,name=orientation,proto3" json:"orientation,omitempty"`                 // EXIF orientation (1-8); 0 if the image carries none.
+	FrameCount    int32                  `protobuf:"varint,6,opt,name=frame_count,json=frameCount,proto3" json:"frame_count,omitempty"` // Number of frames; greater than 1 for animated GIFs.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ThumbnailRequest) Reset() {
-	*x = ThumbnailRequest{}
-	mi := &file_thumbnail_proto_msgTypes[0]
+func (x *ImageInfo) Reset() {
+	*x = ImageInfo{}
+	mi := &file_thumbnail_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ThumbnailRequest) String() string {
+func (x *ImageInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ThumbnailRequest) ProtoMessage() {}
+func (*ImageInfo) ProtoMessage() {}
 
-func (x *ThumbnailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_thumbnail_proto_msgTypes[0]
+func (x *ImageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -115,65 +2992,83 @@ func (x *ThumbnailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ThumbnailRequest.ProtoReflect.Descriptor instead.
-func (*ThumbnailRequest) Descriptor() ([]byte, []int) {
-	return file_thumbnail_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ImageInfo.ProtoReflect.Descriptor instead.
+func (*ImageInfo) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ThumbnailRequest) GetFileContent() []byte {
+func (x *ImageInfo) GetFormat() string {
 	if x != nil {
-		return x.FileContent
+		return x.Format
 	}
-	return nil
+	return ""
 }
 
-func (x *ThumbnailRequest) GetFileType() FileType {
+func (x *ImageInfo) GetWidth() int32 {
 	if x != nil {
-		return x.FileType
+		return x.Width
 	}
-	return FileType_FILE_TYPE_UNSPECIFIED
+	return 0
 }
 
-func (x *ThumbnailRequest) GetMaxWidth() int32 {
+func (x *ImageInfo) GetHeight() int32 {
 	if x != nil {
-		return x.MaxWidth
+		return x.Height
 	}
 	return 0
 }
 
-func (x *ThumbnailRequest) GetMaxHeight() int32 {
+func (x *ImageInfo) GetColorModel() string {
 	if x != nil {
-		return x.MaxHeight
+		return x.ColorModel
+	}
+	return ""
+}
+
+func (x *ImageInfo) GetOrientation() int32 {
+	if x != nil {
+		return x.Orientation
 	}
 	return 0
 }
 
-// Response message for thumbnail generation.
-//
-// Contains a status message and the generated thumbnail as base64-encoded bytes.
-type ThumbnailResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Message          string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`                                           // Status or informational message about the thumbnail generation.
-	ThumbnailContent []byte                 `protobuf:"bytes,2,opt,name=thumbnail_content,json=thumbnailContent,proto3" json:"thumbnail_content,omitempty"` // Base64-encoded bytes of the generated thumbnail image.
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *ImageInfo) GetFrameCount() int32 {
+	if x != nil {
+		return x.FrameCount
+	}
+	return 0
 }
 
-func (x *ThumbnailResponse) Reset() {
-	*x = ThumbnailResponse{}
-	mi := &file_thumbnail_proto_msgTypes[1]
+// Metadata of a PDF file, as reported by pdfinfo.
+type PdfInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageCount     int32                  `protobuf:"varint,1,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`   // Number of pages.
+	PageSizes     []*PdfPageSize         `protobuf:"bytes,2,rep,name=page_sizes,json=pageSizes,proto3" json:"page_sizes,omitempty"`    // Size of every page.
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`                             // Document title.
+	Author        string                 `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`                           // Document author.
+	Creator       string                 `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator,omitempty"`                         // Application that created the original document.
+	Producer      string                 `protobuf:"bytes,6,opt,name=producer,proto3" json:"producer,omitempty"`                       // Application that produced the PDF.
+	PdfVersion    string                 `protobuf:"bytes,7,opt,name=pdf_version,json=pdfVersion,proto3" json:"pdf_version,omitempty"` // PDF version, e.g. 1.7.
+	Encrypted     bool                   `protobuf:"varint,8,opt,name=encrypted,proto3" json:"encrypted,omitempty"`                    // Whether the document is encrypted.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PdfInfo) Reset() {
+	*x = PdfInfo{}
+	mi := &file_thumbnail_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ThumbnailResponse) String() string {
+func (x *PdfInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ThumbnailResponse) ProtoMessage() {}
+func (*PdfInfo) ProtoMessage() {}
 
-func (x *ThumbnailResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_thumbnail_proto_msgTypes[1]
+func (x *PdfInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -184,54 +3079,92 @@ func (x *ThumbnailResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ThumbnailResponse.ProtoReflect.Descriptor instead.
-func (*ThumbnailResponse) Descriptor() ([]byte, []int) {
-	return file_thumbnail_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use PdfInfo.ProtoReflect.Descriptor instead.
+func (*PdfInfo) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *ThumbnailResponse) GetMessage() string {
+func (x *PdfInfo) GetPageCount() int32 {
 	if x != nil {
-		return x.Message
+		return x.PageCount
 	}
-	return ""
+	return 0
 }
 
-func (x *ThumbnailResponse) GetThumbnailContent() []byte {
+func (x *PdfInfo) GetPageSizes() []*PdfPageSize {
 	if x != nil {
-		return x.ThumbnailContent
+		return x.PageSizes
 	}
 	return nil
 }
 
-// Request message for OCR processing.
-//
-// The file_content must be a base64-encoded file.
-// The cleanUp flag indicates if whitespace normalization and character cleanup
-// should be applied to the extracted text.
-type OCRFileRequest struct {
+func (x *PdfInfo) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PdfInfo) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *PdfInfo) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *PdfInfo) GetProducer() string {
+	if x != nil {
+		return x.Producer
+	}
+	return ""
+}
+
+func (x *PdfInfo) GetPdfVersion() string {
+	if x != nil {
+		return x.PdfVersion
+	}
+	return ""
+}
+
+func (x *PdfInfo) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+// Size of a single PDF page in points (1/72 inch).
+type PdfPageSize struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`                         // Base64-encoded bytes of the file to OCR.
-	FileType      FileType               `protobuf:"varint,2,opt,name=file_type,json=fileType,proto3,enum=thumbnail_service.FileType" json:"file_type,omitempty"` // Type of the file for future extensibility.
-	CleanUp       bool                   `protobuf:"varint,3,opt,name=cleanUp,proto3" json:"cleanUp,omitempty"`                                                   // Whether to normalize whitespace and remove unnecessary characters.
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`      // 1-based page number.
+	Width         float64                `protobuf:"fixed64,2,opt,name=width,proto3" json:"width,omitempty"`   // Width of the page in points.
+	Height        float64                `protobuf:"fixed64,3,opt,name=height,proto3" json:"height,omitempty"` // Height of the page in points.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OCRFileRequest) Reset() {
-	*x = OCRFileRequest{}
-	mi := &file_thumbnail_proto_msgTypes[2]
+func (x *PdfPageSize) Reset() {
+	*x = PdfPageSize{}
+	mi := &file_thumbnail_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OCRFileRequest) String() string {
+func (x *PdfPageSize) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OCRFileRequest) ProtoMessage() {}
+func (*PdfPageSize) ProtoMessage() {}
 
-func (x *OCRFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_thumbnail_proto_msgTypes[2]
+func (x *PdfPageSize) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -242,60 +3175,63 @@ func (x *OCRFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OCRFileRequest.ProtoReflect.Descriptor instead.
-func (*OCRFileRequest) Descriptor() ([]byte, []int) {
-	return file_thumbnail_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use PdfPageSize.ProtoReflect.Descriptor instead.
+func (*PdfPageSize) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *OCRFileRequest) GetFileContent() []byte {
+func (x *PdfPageSize) GetPage() int32 {
 	if x != nil {
-		return x.FileContent
+		return x.Page
 	}
-	return nil
+	return 0
 }
 
-func (x *OCRFileRequest) GetFileType() FileType {
+func (x *PdfPageSize) GetWidth() float64 {
 	if x != nil {
-		return x.FileType
+		return x.Width
 	}
-	return FileType_FILE_TYPE_UNSPECIFIED
+	return 0
 }
 
-func (x *OCRFileRequest) GetCleanUp() bool {
+func (x *PdfPageSize) GetHeight() float64 {
 	if x != nil {
-		return x.CleanUp
+		return x.Height
 	}
-	return false
+	return 0
 }
 
-// Response message for OCR processing.
-//
-// Contains a status message, the OCRed file content as bytes, and
-// the extracted text content as a string.
-type OCRFileResponse struct {
+// Metadata of a video file, as reported by ffprobe.
+type VideoInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`                            // Status message about the OCR operation.
-	OcrContent    []byte                 `protobuf:"bytes,2,opt,name=ocr_content,json=ocrContent,proto3" json:"ocr_content,omitempty"`    // Base64-encoded bytes of the OCR processed file.
-	TextContent   string                 `protobuf:"bytes,3,opt,name=text_content,json=textContent,proto3" json:"text_content,omitempty"` // Extracted text content from the file.
+	Duration      float64                `protobuf:"fixed64,1,opt,name=duration,proto3" json:"duration,omitempty"`                     // Duration in seconds.
+	Container     string                 `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`                     // Container format, e.g. mov,mp4,m4a,3gp,3g2,mj2.
+	VideoCodec    string                 `protobuf:"bytes,3,opt,name=video_codec,json=videoCodec,proto3" json:"video_codec,omitempty"` // Codec of the first video stream.
+	Width         int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`                            // Width of the video stream in pixels as stored.
+	Height        int32                  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`                          // Height of the video stream in pixels as stored.
+	Rotation      int32                  `protobuf:"varint,6,opt,name=rotation,proto3" json:"rotation,omitempty"`                      // Display rotation in degrees.
+	BitRate       int64                  `protobuf:"varint,7,opt,name=bit_rate,json=bitRate,proto3" json:"bit_rate,omitempty"`         // Overall bit rate in bits per second.
+	FrameRate     float64                `protobuf:"fixed64,8,opt,name=frame_rate,json=frameRate,proto3" json:"frame_rate,omitempty"`  // Frames per second of the video stream.
+	AudioCodec    string                 `protobuf:"bytes,9,opt,name=audio_codec,json=audioCodec,proto3" json:"audio_codec,omitempty"` // Codec of the first audio stream; empty if there is none.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OCRFileResponse) Reset() {
-	*x = OCRFileResponse{}
-	mi := &file_thumbnail_proto_msgTypes[3]
+func (x *VideoInfo) Reset() {
+	*x = VideoInfo{}
+	mi := &file_thumbnail_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OCRFileResponse) String() string {
+func (x *VideoInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OCRFileResponse) ProtoMessage() {}
+func (*VideoInfo) ProtoMessage() {}
 
-func (x *OCRFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_thumbnail_proto_msgTypes[3]
+func (x *VideoInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_thumbnail_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -306,28 +3242,70 @@ func (x *OCRFileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OCRFileResponse.ProtoReflect.Descriptor instead.
-func (*OCRFileResponse) Descriptor() ([]byte, []int) {
-	return file_thumbnail_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use VideoInfo.ProtoReflect.Descriptor instead.
+func (*VideoInfo) Descriptor() ([]byte, []int) {
+	return file_thumbnail_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *OCRFileResponse) GetMessage() string {
+func (x *VideoInfo) GetDuration() float64 {
 	if x != nil {
-		return x.Message
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *VideoInfo) GetContainer() string {
+	if x != nil {
+		return x.Container
 	}
 	return ""
 }
 
-func (x *OCRFileResponse) GetOcrContent() []byte {
+func (x *VideoInfo) GetVideoCodec() string {
 	if x != nil {
-		return x.OcrContent
+		return x.VideoCodec
 	}
-	return nil
+	return ""
 }
 
-func (x *OCRFileResponse) GetTextContent() string {
+func (x *VideoInfo) GetWidth() int32 {
 	if x != nil {
-		return x.TextContent
+		return x.Width
+	}
+	return 0
+}
+
+func (x *VideoInfo) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *VideoInfo) GetRotation() int32 {
+	if x != nil {
+		return x.Rotation
+	}
+	return 0
+}
+
+func (x *VideoInfo) GetBitRate() int64 {
+	if x != nil {
+		return x.BitRate
+	}
+	return 0
+}
+
+func (x *VideoInfo) GetFrameRate() float64 {
+	if x != nil {
+		return x.FrameRate
+	}
+	return 0
+}
+
+func (x *VideoInfo) GetAudioCodec() string {
+	if x != nil {
+		return x.AudioCodec
 	}
 	return ""
 }
@@ -336,33 +3314,300 @@ var File_thumbnail_proto protoreflect.FileDescriptor
 
 const file_thumbnail_proto_rawDesc = "" +
 	"\n" +
-	"\x0fthumbnail.proto\x12\x11thumbnail_service\x1a\x1cgoogle/api/annotations.proto\"\xab\x01\n" +
+	"\x0fthumbnail.proto\x12\x11thumbnail_service\x1a\x1cgoogle/api/annotations.proto\"\xed\a\n" +
 	"\x10ThumbnailRequest\x12!\n" +
 	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x128\n" +
 	"\tfile_type\x18\x02 \x01(\x0e2\x1b.thumbnail_service.FileTypeR\bfileType\x12\x1b\n" +
 	"\tmax_width\x18\x03 \x01(\x05R\bmaxWidth\x12\x1d\n" +
 	"\n" +
-	"max_height\x18\x04 \x01(\x05R\tmaxHeight\"Z\n" +
+	"max_height\x18\x04 \x01(\x05R\tmaxHeight\x126\n" +
+	"\x05sizes\x18\x05 \x03(\v2 .thumbnail_service.ThumbnailSizeR\x05sizes\x12D\n" +
+	"\routput_format\x18\x06 \x01(\x0e2\x1f.thumbnail_service.OutputFormatR\foutputFormat\x12\x18\n" +
+	"\aquality\x18\a \x01(\x05R\aquality\x12J\n" +
+	"\x0fpng_compression\x18\b \x01(\x0e2!.thumbnail_service.PngCompressionR\x0epngCompression\x125\n" +
+	"\bfit_mode\x18\t \x01(\x0e2\x1a.thumbnail_service.FitModeR\afitMode\x12)\n" +
+	"\x10background_color\x18\n" +
+	" \x01(\tR\x0fbackgroundColor\x12>\n" +
+	"\vfocal_point\x18\v \x01(\v2\x1d.thumbnail_service.FocalPointR\n" +
+	"focalPoint\x12\x12\n" +
+	"\x04page\x18\f \x01(\x05R\x04page\x12K\n" +
+	"\rcontact_sheet\x18\r \x01(\v2&.thumbnail_service.ContactSheetOptionsR\fcontactSheet\x12G\n" +
+	"\vvideo_frame\x18\x0e \x01(\v2&.thumbnail_service.VideoFrameSelectionR\n" +
+	"videoFrame\x12D\n" +
+	"\n" +
+	"storyboard\x18\x0f \x01(\v2$.thumbnail_service.StoryboardOptionsR\n" +
+	"storyboard\x12T\n" +
+	"\x10animated_preview\x18\x10 \x01(\v2).thumbnail_service.AnimatedPreviewOptionsR\x0fanimatedPreview\x12(\n" +
+	"\x10first_frame_only\x18\x11 \x01(\bR\x0efirstFrameOnly\x12.\n" +
+	"\x13disable_auto_orient\x18\x12 \x01(\bR\x11disableAutoOrient\x12\x1a\n" +
+	"\bpassword\x18\x13 \x01(\tR\bpassword\"K\n" +
+	"\rThumbnailSize\x12\x1b\n" +
+	"\tmax_width\x18\x01 \x01(\x05R\bmaxWidth\x12\x1d\n" +
+	"\n" +
+	"max_height\x18\x02 \x01(\x05R\tmaxHeight\"\x83\x01\n" +
+	"\x13ContactSheetOptions\x12\x1b\n" +
+	"\tlast_page\x18\x01 \x01(\x05R\blastPage\x12\x18\n" +
+	"\acolumns\x18\x02 \x01(\x05R\acolumns\x12\x1b\n" +
+	"\ttile_size\x18\x03 \x01(\x05R\btileSize\x12\x18\n" +
+	"\aspacing\x18\x04 \x01(\x05R\aspacing\"\xac\x01\n" +
+	"\x13VideoFrameSelection\x12\x1e\n" +
+	"\ttimestamp\x18\x01 \x01(\x01H\x00R\ttimestamp\x12 \n" +
+	"\n" +
+	"percentage\x18\x02 \x01(\x01H\x00R\n" +
+	"percentage\x12F\n" +
+	"\x0ebest_in_window\x18\x03 \x01(\v2\x1e.thumbnail_service.FrameWindowH\x00R\fbestInWindowB\v\n" +
+	"\tselection\"?\n" +
+	"\vFrameWindow\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x01R\x05start\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\x01R\bduration\"|\n" +
+	"\x11StoryboardOptions\x12\x1a\n" +
+	"\binterval\x18\x01 \x01(\x01R\binterval\x12\x1d\n" +
+	"\n" +
+	"tile_width\x18\x02 \x01(\x05R\ttileWidth\x12\x18\n" +
+	"\acolumns\x18\x03 \x01(\x05R\acolumns\x12\x12\n" +
+	"\x04rows\x18\x04 \x01(\x05R\x04rows\"n\n" +
+	"\x10StoryboardSprite\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\fR\acontent\"]\n" +
+	"\n" +
+	"Storyboard\x12=\n" +
+	"\asprites\x18\x01 \x03(\v2#.thumbnail_service.StoryboardSpriteR\asprites\x12\x10\n" +
+	"\x03vtt\x18\x02 \x01(\tR\x03vtt\"O\n" +
+	"\x16AnimatedPreviewOptions\x12\x1f\n" +
+	"\vframe_count\x18\x01 \x01(\x05R\n" +
+	"frameCount\x12\x14\n" +
+	"\x05delay\x18\x02 \x01(\x05R\x05delay\"(\n" +
+	"\n" +
+	"FocalPoint\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x02R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x02R\x01y\"T\n" +
+	"\bCropRect\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\"\x8d\x01\n" +
+	"\x12ThumbnailRendition\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\x12/\n" +
+	"\x04crop\x18\x04 \x01(\v2\x1b.thumbnail_service.CropRectR\x04crop\"{\n" +
+	"\x16ThumbnailStreamRequest\x12A\n" +
+	"\bmetadata\x18\x01 \x01(\v2#.thumbnail_service.ThumbnailRequestH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"\xc6\x03\n" +
 	"\x11ThumbnailResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12+\n" +
-	"\x11thumbnail_content\x18\x02 \x01(\fR\x10thumbnailContent\"\x87\x01\n" +
+	"\x11thumbnail_content\x18\x02 \x01(\fR\x10thumbnailContent\x12E\n" +
+	"\n" +
+	"renditions\x18\x03 \x03(\v2%.thumbnail_service.ThumbnailRenditionR\n" +
+	"renditions\x12\x1b\n" +
+	"\tmime_type\x18\x04 \x01(\tR\bmimeType\x12I\n" +
+	"\x12detected_file_type\x18\x05 \x01(\x0e2\x1b.thumbnail_service.FileTypeR\x10detectedFileType\x12/\n" +
+	"\x04crop\x18\x06 \x01(\v2\x1b.thumbnail_service.CropRectR\x04crop\x12=\n" +
+	"\n" +
+	"storyboard\x18\a \x01(\v2\x1d.thumbnail_service.StoryboardR\n" +
+	"storyboard\x12/\n" +
+	"\x13applied_orientation\x18\b \x01(\x05R\x12appliedOrientation\x12\x1a\n" +
+	"\brepaired\x18\t \x01(\bR\brepaired\"c\n" +
+	"\x12ThumbnailBatchItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
+	"\arequest\x18\x02 \x01(\v2#.thumbnail_service.ThumbnailRequestR\arequest\"T\n" +
+	"\x15ThumbnailBatchRequest\x12;\n" +
+	"\x05items\x18\x01 \x03(\v2%.thumbnail_service.ThumbnailBatchItemR\x05items\"\x98\x01\n" +
+	"\x14ThumbnailBatchResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12@\n" +
+	"\bresponse\x18\x04 \x01(\v2$.thumbnail_service.ThumbnailResponseR\bresponse\"u\n" +
+	"\x16ThumbnailBatchResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12A\n" +
+	"\aresults\x18\x02 \x03(\v2'.thumbnail_service.ThumbnailBatchResultR\aresults\"\xb9\x03\n" +
 	"\x0eOCRFileRequest\x12!\n" +
 	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x128\n" +
-	"\tfile_type\x18\x02 \x01(\x0e2\x1b.thumbnail_service.FileTypeR\bfileType\x12\x18\n" +
-	"\acleanUp\x18\x03 \x01(\bR\acleanUp\"o\n" +
+	"\tfile_type\x18\x02 \x01(\x0e2\x1b.thumbnail_service.FileTypeR\bfileType\x12\x1c\n" +
+	"\acleanUp\x18\x03 \x01(\bB\x02\x18\x01R\acleanUp\x12\x1c\n" +
+	"\tlanguages\x18\x04 \x03(\tR\tlanguages\x12'\n" +
+	"\x0fdetect_language\x18\x05 \x01(\bR\x0edetectLanguage\x12D\n" +
+	"\rlayout_format\x18\x06 \x01(\x0e2\x1f.thumbnail_service.LayoutFormatR\flayoutFormat\x12\x1a\n" +
+	"\bpassword\x18\a \x01(\tR\bpassword\x127\n" +
+	"\aoptions\x18\b \x01(\v2\x1d.thumbnail_service.OcrOptionsR\aoptions\x12J\n" +
+	"\x0fcleanup_options\x18\t \x01(\v2!.thumbnail_service.CleanupOptionsR\x0ecleanupOptions\"\x92\x03\n" +
+	"\x0eCleanupOptions\x128\n" +
+	"\x18strip_control_characters\x18\x01 \x01(\bR\x16stripControlCharacters\x12+\n" +
+	"\x11normalize_unicode\x18\x02 \x01(\bR\x10normalizeUnicode\x12)\n" +
+	"\x10expand_ligatures\x18\x03 \x01(\bR\x0fexpandLigatures\x124\n" +
+	"\x16remove_headers_footers\x18\x04 \x01(\bR\x14removeHeadersFooters\x124\n" +
+	"\x16remove_separator_lines\x18\x05 \x01(\bR\x14removeSeparatorLines\x12 \n" +
+	"\vdehyphenate\x18\x06 \x01(\bR\vdehyphenate\x12/\n" +
+	"\x13collapse_whitespace\x18\a \x01(\bR\x12collapseWhitespace\x12/\n" +
+	"\x13preserve_paragraphs\x18\b \x01(\bR\x12preserveParagraphs\"\xaf\x02\n" +
+	"\n" +
+	"OcrOptions\x12\x16\n" +
+	"\x06deskew\x18\x01 \x01(\bR\x06deskew\x12!\n" +
+	"\frotate_pages\x18\x02 \x01(\bR\vrotatePages\x12\x14\n" +
+	"\x05clean\x18\x03 \x01(\bR\x05clean\x12\x1f\n" +
+	"\vclean_final\x18\x04 \x01(\bR\n" +
+	"cleanFinal\x12.\n" +
+	"\x04mode\x18\x05 \x01(\x0e2\x1a.thumbnail_service.OcrModeR\x04mode\x12<\n" +
+	"\boptimize\x18\x06 \x01(\x0e2 .thumbnail_service.OptimizeLevelR\boptimize\x12A\n" +
+	"\voutput_type\x18\a \x01(\x0e2 .thumbnail_service.OcrOutputTypeR\n" +
+	"outputType\"\xcf\x03\n" +
 	"\x0fOCRFileResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1f\n" +
 	"\vocr_content\x18\x02 \x01(\fR\n" +
 	"ocrContent\x12!\n" +
-	"\ftext_content\x18\x03 \x01(\tR\vtextContent*D\n" +
+	"\ftext_content\x18\x03 \x01(\tR\vtextContent\x12I\n" +
+	"\x12detected_file_type\x18\x04 \x01(\x0e2\x1b.thumbnail_service.FileTypeR\x10detectedFileType\x12\x1c\n" +
+	"\tlanguages\x18\x05 \x03(\tR\tlanguages\x12%\n" +
+	"\x0elayout_content\x18\x06 \x01(\tR\rlayoutContent\x125\n" +
+	"\x06layout\x18\a \x03(\v2\x1d.thumbnail_service.LayoutPageR\x06layout\x120\n" +
+	"\x05pages\x18\b \x03(\v2\x1a.thumbnail_service.OCRPageR\x05pages\x12\x1a\n" +
+	"\brepaired\x18\t \x01(\bR\brepaired\x12\x1b\n" +
+	"\tocr_pages\x18\n" +
+	" \x03(\x05R\bocrPages\x12,\n" +
+	"\x12claimed_pdfa_level\x18\v \x01(\tR\x10claimedPdfaLevel\"\xb2\x02\n" +
+	"\fOCRFileChunk\x12#\n" +
+	"\ftext_content\x18\x01 \x01(\tH\x00R\vtextContent\x12!\n" +
+	"\vocr_content\x18\x02 \x01(\fH\x00R\n" +
+	"ocrContent\x12>\n" +
+	"\asummary\x18\x03 \x01(\v2\".thumbnail_service.OCRFileResponseH\x00R\asummary\x120\n" +
+	"\x04page\x18\x04 \x01(\v2\x1a.thumbnail_service.OCRPageH\x00R\x04page\x12'\n" +
+	"\x0elayout_content\x18\x05 \x01(\tH\x00R\rlayoutContent\x127\n" +
+	"\x06layout\x18\x06 \x01(\v2\x1d.thumbnail_service.LayoutPageH\x00R\x06layoutB\x06\n" +
+	"\x04data\"s\n" +
+	"\aOCRPage\x12\x1f\n" +
+	"\vpage_number\x18\x01 \x01(\x05R\n" +
+	"pageNumber\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05ocred\x18\x03 \x01(\bR\x05ocred\x12\x1d\n" +
+	"\n" +
+	"char_count\x18\x04 \x01(\x05R\tcharCount\"\x90\x01\n" +
+	"\n" +
+	"LayoutPage\x12\x1f\n" +
+	"\vpage_number\x18\x01 \x01(\x05R\n" +
+	"pageNumber\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x01R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x01R\x06height\x123\n" +
+	"\x05lines\x18\x04 \x03(\v2\x1d.thumbnail_service.LayoutLineR\x05lines\"u\n" +
+	"\n" +
+	"LayoutLine\x122\n" +
+	"\x04bbox\x18\x01 \x01(\v2\x1e.thumbnail_service.BoundingBoxR\x04bbox\x123\n" +
+	"\x05words\x18\x02 \x03(\v2\x1d.thumbnail_service.LayoutWordR\x05words\"t\n" +
+	"\n" +
+	"LayoutWord\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x122\n" +
+	"\x04bbox\x18\x02 \x01(\v2\x1e.thumbnail_service.BoundingBoxR\x04bbox\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x03 \x01(\x02R\n" +
+	"confidence\"a\n" +
+	"\vBoundingBox\x12\x13\n" +
+	"\x05x_min\x18\x01 \x01(\x01R\x04xMin\x12\x13\n" +
+	"\x05y_min\x18\x02 \x01(\x01R\x04yMin\x12\x13\n" +
+	"\x05x_max\x18\x03 \x01(\x01R\x04xMax\x12\x13\n" +
+	"\x05y_max\x18\x04 \x01(\x01R\x04yMax\"n\n" +
+	"\x0fFileInfoRequest\x12!\n" +
+	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x128\n" +
+	"\tfile_type\x18\x02 \x01(\x0e2\x1b.thumbnail_service.FileTypeR\bfileType\"\xaf\x02\n" +
+	"\x10FileInfoResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12I\n" +
+	"\x12detected_file_type\x18\x02 \x01(\x0e2\x1b.thumbnail_service.FileTypeR\x10detectedFileType\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x124\n" +
+	"\x05image\x18\x04 \x01(\v2\x1c.thumbnail_service.ImageInfoH\x00R\x05image\x12.\n" +
+	"\x03pdf\x18\x05 \x01(\v2\x1a.thumbnail_service.PdfInfoH\x00R\x03pdf\x124\n" +
+	"\x05video\x18\x06 \x01(\v2\x1c.thumbnail_service.VideoInfoH\x00R\x05videoB\x06\n" +
+	"\x04info\"\xb5\x01\n" +
+	"\tImageInfo\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x1f\n" +
+	"\vcolor_model\x18\x04 \x01(\tR\n" +
+	"colorModel\x12 \n" +
+	"\vorientation\x18\x05 \x01(\x05R\vorientation\x12\x1f\n" +
+	"\vframe_count\x18\x06 \x01(\x05R\n" +
+	"frameCount\"\x8a\x02\n" +
+	"\aPdfInfo\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\x01 \x01(\x05R\tpageCount\x12=\n" +
+	"\n" +
+	"page_sizes\x18\x02 \x03(\v2\x1e.thumbnail_service.PdfPageSizeR\tpageSizes\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x16\n" +
+	"\x06author\x18\x04 \x01(\tR\x06author\x12\x18\n" +
+	"\acreator\x18\x05 \x01(\tR\acreator\x12\x1a\n" +
+	"\bproducer\x18\x06 \x01(\tR\bproducer\x12\x1f\n" +
+	"\vpdf_version\x18\a \x01(\tR\n" +
+	"pdfVersion\x12\x1c\n" +
+	"\tencrypted\x18\b \x01(\bR\tencrypted\"O\n" +
+	"\vPdfPageSize\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x01R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x01R\x06height\"\x8b\x02\n" +
+	"\tVideoInfo\x12\x1a\n" +
+	"\bduration\x18\x01 \x01(\x01R\bduration\x12\x1c\n" +
+	"\tcontainer\x18\x02 \x01(\tR\tcontainer\x12\x1f\n" +
+	"\vvideo_codec\x18\x03 \x01(\tR\n" +
+	"videoCodec\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\x05R\x06height\x12\x1a\n" +
+	"\brotation\x18\x06 \x01(\x05R\brotation\x12\x19\n" +
+	"\bbit_rate\x18\a \x01(\x03R\abitRate\x12\x1d\n" +
+	"\n" +
+	"frame_rate\x18\b \x01(\x01R\tframeRate\x12\x1f\n" +
+	"\vaudio_codec\x18\t \x01(\tR\n" +
+	"audioCodec*D\n" +
 	"\bFileType\x12\x19\n" +
 	"\x15FILE_TYPE_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05IMAGE\x10\x01\x12\t\n" +
 	"\x05VIDEO\x10\x02\x12\a\n" +
-	"\x03PDF\x10\x032\xf2\x01\n" +
+	"\x03PDF\x10\x03*S\n" +
+	"\fOutputFormat\x12\x1d\n" +
+	"\x19OUTPUT_FORMAT_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04JPEG\x10\x01\x12\a\n" +
+	"\x03PNG\x10\x02\x12\a\n" +
+	"\x03GIF\x10\x03\x12\b\n" +
+	"\x04WEBP\x10\x04*Y\n" +
+	"\aFitMode\x12\x18\n" +
+	"\x14FIT_MODE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aCONTAIN\x10\x01\x12\t\n" +
+	"\x05COVER\x10\x02\x12\b\n" +
+	"\x04FILL\x10\x03\x12\a\n" +
+	"\x03PAD\x10\x04\x12\t\n" +
+	"\x05SMART\x10\x05*\x81\x01\n" +
+	"\x0ePngCompression\x12\x1b\n" +
+	"\x17PNG_COMPRESSION_DEFAULT\x10\x00\x12\x18\n" +
+	"\x14PNG_COMPRESSION_NONE\x10\x01\x12\x1e\n" +
+	"\x1aPNG_COMPRESSION_BEST_SPEED\x10\x02\x12\x18\n" +
+	"\x14PNG_COMPRESSION_BEST\x10\x03*:\n" +
+	"\fLayoutFormat\x12\x16\n" +
+	"\x12LAYOUT_FORMAT_NONE\x10\x00\x12\b\n" +
+	"\x04HOCR\x10\x01\x12\b\n" +
+	"\x04ALTO\x10\x02*H\n" +
+	"\aOcrMode\x12\x11\n" +
+	"\rOCR_MODE_AUTO\x10\x00\x12\r\n" +
+	"\tSKIP_TEXT\x10\x01\x12\r\n" +
+	"\tFORCE_OCR\x10\x02\x12\f\n" +
+	"\bREDO_OCR\x10\x03*|\n" +
+	"\rOptimizeLevel\x12\x14\n" +
+	"\x10OPTIMIZE_DEFAULT\x10\x00\x12\x11\n" +
+	"\rOPTIMIZE_NONE\x10\x01\x12\x15\n" +
+	"\x11OPTIMIZE_LOSSLESS\x10\x02\x12\x12\n" +
+	"\x0eOPTIMIZE_LOSSY\x10\x03\x12\x17\n" +
+	"\x13OPTIMIZE_AGGRESSIVE\x10\x04*\x9e\x01\n" +
+	"\rOcrOutputType\x12\x1f\n" +
+	"\x1bOCR_OUTPUT_TYPE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eOCR_OUTPUT_PDF\x10\x01\x12\x13\n" +
+	"\x0fOCR_OUTPUT_PDFA\x10\x02\x12\x15\n" +
+	"\x11OCR_OUTPUT_PDFA_1\x10\x03\x12\x15\n" +
+	"\x11OCR_OUTPUT_PDFA_2\x10\x04\x12\x15\n" +
+	"\x11OCR_OUTPUT_PDFA_3\x10\x052\xaf\x05\n" +
 	"\x10ThumbnailService\x12x\n" +
-	"\x11GenerateThumbnail\x12#.thumbnail_service.ThumbnailRequest\x1a$.thumbnail_service.ThumbnailResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/thumbnail\x12d\n" +
-	"\aOcrFile\x12!.thumbnail_service.OCRFileRequest\x1a\".thumbnail_service.OCRFileResponse\"\x12\x82\xd3\xe4\x93\x02\f:\x01*\"\a/v1/ocrB\tZ\a./protob\x06proto3"
+	"\x11GenerateThumbnail\x12#.thumbnail_service.ThumbnailRequest\x1a$.thumbnail_service.ThumbnailResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/thumbnail\x12l\n" +
+	"\x17GenerateThumbnailStream\x12).thumbnail_service.ThumbnailStreamRequest\x1a$.thumbnail_service.ThumbnailResponse(\x01\x12\x84\x01\n" +
+	"\x12GenerateThumbnails\x12(.thumbnail_service.ThumbnailBatchRequest\x1a).thumbnail_service.ThumbnailBatchResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/thumbnails\x12d\n" +
+	"\aOcrFile\x12!.thumbnail_service.OCRFileRequest\x1a\".thumbnail_service.OCRFileResponse\"\x12\x82\xd3\xe4\x93\x02\f:\x01*\"\a/v1/ocr\x12U\n" +
+	"\rOcrFileStream\x12!.thumbnail_service.OCRFileRequest\x1a\x1f.thumbnail_service.OCRFileChunk0\x01\x12o\n" +
+	"\vGetFileInfo\x12\".thumbnail_service.FileInfoRequest\x1a#.thumbnail_service.FileInfoResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/fileinfoB\tZ\a./protob\x06proto3"
 
 var (
 	file_thumbnail_proto_rawDescOnce sync.Once
@@ -376,27 +3621,115 @@ func file_thumbnail_proto_rawDescGZIP() []byte {
 	return file_thumbnail_proto_rawDescData
 }
 
-var file_thumbnail_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_thumbnail_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_thumbnail_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_thumbnail_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_thumbnail_proto_goTypes = []any{
-	(FileType)(0),             // 0: thumbnail_service.FileType
-	(*ThumbnailRequest)(nil),  // 1: thumbnail_service.ThumbnailRequest
-	(*ThumbnailResponse)(nil), // 2: thumbnail_service.ThumbnailResponse
-	(*OCRFileRequest)(nil),    // 3: thumbnail_service.OCRFileRequest
-	(*OCRFileResponse)(nil),   // 4: thumbnail_service.OCRFileResponse
+	(FileType)(0),                  // 0: thumbnail_service.FileType
+	(OutputFormat)(0),              // 1: thumbnail_service.OutputFormat
+	(FitMode)(0),                   // 2: thumbnail_service.FitMode
+	(PngCompression)(0),            // 3: thumbnail_service.PngCompression
+	(LayoutFormat)(0),              // 4: thumbnail_service.LayoutFormat
+	(OcrMode)(0),                   // 5: thumbnail_service.OcrMode
+	(OptimizeLevel)(0),             // 6: thumbnail_service.OptimizeLevel
+	(OcrOutputType)(0),             // 7: thumbnail_service.OcrOutputType
+	(*ThumbnailRequest)(nil),       // 8: thumbnail_service.ThumbnailRequest
+	(*ThumbnailSize)(nil),          // 9: thumbnail_service.ThumbnailSize
+	(*ContactSheetOptions)(nil),    // 10: thumbnail_service.ContactSheetOptions
+	(*VideoFrameSelection)(nil),    // 11: thumbnail_service.VideoFrameSelection
+	(*FrameWindow)(nil),            // 12: thumbnail_service.FrameWindow
+	(*StoryboardOptions)(nil),      // 13: thumbnail_service.StoryboardOptions
+	(*StoryboardSprite)(nil),       // 14: thumbnail_service.StoryboardSprite
+	(*Storyboard)(nil),             // 15: thumbnail_service.Storyboard
+	(*AnimatedPreviewOptions)(nil), // 16: thumbnail_service.AnimatedPreviewOptions
+	(*FocalPoint)(nil),             // 17: thumbnail_service.FocalPoint
+	(*CropRect)(nil),               // 18: thumbnail_service.CropRect
+	(*ThumbnailRendition)(nil),     // 19: thumbnail_service.ThumbnailRendition
+	(*ThumbnailStreamRequest)(nil), // 20: thumbnail_service.ThumbnailStreamRequest
+	(*ThumbnailResponse)(nil),      // 21: thumbnail_service.ThumbnailResponse
+	(*ThumbnailBatchItem)(nil),     // 22: thumbnail_service.ThumbnailBatchItem
+	(*ThumbnailBatchRequest)(nil),  // 23: thumbnail_service.ThumbnailBatchRequest
+	(*ThumbnailBatchResult)(nil),   // 24: thumbnail_service.ThumbnailBatchResult
+	(*ThumbnailBatchResponse)(nil), // 25: thumbnail_service.ThumbnailBatchResponse
+	(*OCRFileRequest)(nil),         // 26: thumbnail_service.OCRFileRequest
+	(*CleanupOptions)(nil),         // 27: thumbnail_service.CleanupOptions
+	(*OcrOptions)(nil),             // 28: thumbnail_service.OcrOptions
+	(*OCRFileResponse)(nil),        // 29: thumbnail_service.OCRFileResponse
+	(*OCRFileChunk)(nil),           // 30: thumbnail_service.OCRFileChunk
+	(*OCRPage)(nil),                // 31: thumbnail_service.OCRPage
+	(*LayoutPage)(nil),             // 32: thumbnail_service.LayoutPage
+	(*LayoutLine)(nil),             // 33: thumbnail_service.LayoutLine
+	(*LayoutWord)(nil),             // 34: thumbnail_service.LayoutWord
+	(*BoundingBox)(nil),            // 35: thumbnail_service.BoundingBox
+	(*FileInfoRequest)(nil),        // 36: thumbnail_service.FileInfoRequest
+	(*FileInfoResponse)(nil),       // 37: thumbnail_service.FileInfoResponse
+	(*ImageInfo)(nil),              // 38: thumbnail_service.ImageInfo
+	(*PdfInfo)(nil),                // 39: thumbnail_service.PdfInfo
+	(*PdfPageSize)(nil),            // 40: thumbnail_service.PdfPageSize
+	(*VideoInfo)(nil),              // 41: thumbnail_service.VideoInfo
 }
 var file_thumbnail_proto_depIdxs = []int32{
-	0, // 0: thumbnail_service.ThumbnailRequest.file_type:type_name -> thumbnail_service.FileType
-	0, // 1: thumbnail_service.OCRFileRequest.file_type:type_name -> thumbnail_service.FileType
-	1, // 2: thumbnail_service.ThumbnailService.GenerateThumbnail:input_type -> thumbnail_service.ThumbnailRequest
-	3, // 3: thumbnail_service.ThumbnailService.OcrFile:input_type -> thumbnail_service.OCRFileRequest
-	2, // 4: thumbnail_service.ThumbnailService.GenerateThumbnail:output_type -> thumbnail_service.ThumbnailResponse
-	4, // 5: thumbnail_service.ThumbnailService.OcrFile:output_type -> thumbnail_service.OCRFileResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: thumbnail_service.ThumbnailRequest.file_type:type_name -> thumbnail_service.FileType
+	9,  // 1: thumbnail_service.ThumbnailRequest.sizes:type_name -> thumbnail_service.ThumbnailSize
+	1,  // 2: thumbnail_service.ThumbnailRequest.output_format:type_name -> thumbnail_service.OutputFormat
+	3,  // 3: thumbnail_service.ThumbnailRequest.png_compression:type_name -> thumbnail_service.PngCompression
+	2,  // 4: thumbnail_service.ThumbnailRequest.fit_mode:type_name -> thumbnail_service.FitMode
+	17, // 5: thumbnail_service.ThumbnailRequest.focal_point:type_name -> thumbnail_service.FocalPoint
+	10, // 6: thumbnail_service.ThumbnailRequest.contact_sheet:type_name -> thumbnail_service.ContactSheetOptions
+	11, // 7: thumbnail_service.ThumbnailRequest.video_frame:type_name -> thumbnail_service.VideoFrameSelection
+	13, // 8: thumbnail_service.ThumbnailRequest.storyboard:type_name -> thumbnail_service.StoryboardOptions
+	16, // 9: thumbnail_service.ThumbnailRequest.animated_preview:type_name -> thumbnail_service.AnimatedPreviewOptions
+	12, // 10: thumbnail_service.VideoFrameSelection.best_in_window:type_name -> thumbnail_service.FrameWindow
+	14, // 11: thumbnail_service.Storyboard.sprites:type_name -> thumbnail_service.StoryboardSprite
+	18, // 12: thumbnail_service.ThumbnailRendition.crop:type_name -> thumbnail_service.CropRect
+	8,  // 13: thumbnail_service.ThumbnailStreamRequest.metadata:type_name -> thumbnail_service.ThumbnailRequest
+	19, // 14: thumbnail_service.ThumbnailResponse.renditions:type_name -> thumbnail_service.ThumbnailRendition
+	0,  // 15: thumbnail_service.ThumbnailResponse.detected_file_type:type_name -> thumbnail_service.FileType
+	18, // 16: thumbnail_service.ThumbnailResponse.crop:type_name -> thumbnail_service.CropRect
+	15, // 17: thumbnail_service.ThumbnailResponse.storyboard:type_name -> thumbnail_service.Storyboard
+	8,  // 18: thumbnail_service.ThumbnailBatchItem.request:type_name -> thumbnail_service.ThumbnailRequest
+	22, // 19: thumbnail_service.ThumbnailBatchRequest.items:type_name -> thumbnail_service.ThumbnailBatchItem
+	21, // 20: thumbnail_service.ThumbnailBatchResult.response:type_name -> thumbnail_service.ThumbnailResponse
+	24, // 21: thumbnail_service.ThumbnailBatchResponse.results:type_name -> thumbnail_service.ThumbnailBatchResult
+	0,  // 22: thumbnail_service.OCRFileRequest.file_type:type_name -> thumbnail_service.FileType
+	4,  // 23: thumbnail_service.OCRFileRequest.layout_format:type_name -> thumbnail_service.LayoutFormat
+	28, // 24: thumbnail_service.OCRFileRequest.options:type_name -> thumbnail_service.OcrOptions
+	27, // 25: thumbnail_service.OCRFileRequest.cleanup_options:type_name -> thumbnail_service.CleanupOptions
+	5,  // 26: thumbnail_service.OcrOptions.mode:type_name -> thumbnail_service.OcrMode
+	6,  // 27: thumbnail_service.OcrOptions.optimize:type_name -> thumbnail_service.OptimizeLevel
+	7,  // 28: thumbnail_service.OcrOptions.output_type:type_name -> thumbnail_service.OcrOutputType
+	0,  // 29: thumbnail_service.OCRFileResponse.detected_file_type:type_name -> thumbnail_service.FileType
+	32, // 30: thumbnail_service.OCRFileResponse.layout:type_name -> thumbnail_service.LayoutPage
+	31, // 31: thumbnail_service.OCRFileResponse.pages:type_name -> thumbnail_service.OCRPage
+	29, // 32: thumbnail_service.OCRFileChunk.summary:type_name -> thumbnail_service.OCRFileResponse
+	31, // 33: thumbnail_service.OCRFileChunk.page:type_name -> thumbnail_service.OCRPage
+	32, // 34: thumbnail_service.OCRFileChunk.layout:type_name -> thumbnail_service.LayoutPage
+	33, // 35: thumbnail_service.LayoutPage.lines:type_name -> thumbnail_service.LayoutLine
+	35, // 36: thumbnail_service.LayoutLine.bbox:type_name -> thumbnail_service.BoundingBox
+	34, // 37: thumbnail_service.LayoutLine.words:type_name -> thumbnail_service.LayoutWord
+	35, // 38: thumbnail_service.LayoutWord.bbox:type_name -> thumbnail_service.BoundingBox
+	0,  // 39: thumbnail_service.FileInfoRequest.file_type:type_name -> thumbnail_service.FileType
+	0,  // 40: thumbnail_service.FileInfoResponse.detected_file_type:type_name -> thumbnail_service.FileType
+	38, // 41: thumbnail_service.FileInfoResponse.image:type_name -> thumbnail_service.ImageInfo
+	39, // 42: thumbnail_service.FileInfoResponse.pdf:type_name -> thumbnail_service.PdfInfo
+	41, // 43: thumbnail_service.FileInfoResponse.video:type_name -> thumbnail_service.VideoInfo
+	40, // 44: thumbnail_service.PdfInfo.page_sizes:type_name -> thumbnail_service.PdfPageSize
+	8,  // 45: thumbnail_service.ThumbnailService.GenerateThumbnail:input_type -> thumbnail_service.ThumbnailRequest
+	20, // 46: thumbnail_service.ThumbnailService.GenerateThumbnailStream:input_type -> thumbnail_service.ThumbnailStreamRequest
+	23, // 47: thumbnail_service.ThumbnailService.GenerateThumbnails:input_type -> thumbnail_service.ThumbnailBatchRequest
+	26, // 48: thumbnail_service.ThumbnailService.OcrFile:input_type -> thumbnail_service.OCRFileRequest
+	26, // 49: thumbnail_service.ThumbnailService.OcrFileStream:input_type -> thumbnail_service.OCRFileRequest
+	36, // 50: thumbnail_service.ThumbnailService.GetFileInfo:input_type -> thumbnail_service.FileInfoRequest
+	21, // 51: thumbnail_service.ThumbnailService.GenerateThumbnail:output_type -> thumbnail_service.ThumbnailResponse
+	21, // 52: thumbnail_service.ThumbnailService.GenerateThumbnailStream:output_type -> thumbnail_service.ThumbnailResponse
+	25, // 53: thumbnail_service.ThumbnailService.GenerateThumbnails:output_type -> thumbnail_service.ThumbnailBatchResponse
+	29, // 54: thumbnail_service.ThumbnailService.OcrFile:output_type -> thumbnail_service.OCRFileResponse
+	30, // 55: thumbnail_service.ThumbnailService.OcrFileStream:output_type -> thumbnail_service.OCRFileChunk
+	37, // 56: thumbnail_service.ThumbnailService.GetFileInfo:output_type -> thumbnail_service.FileInfoResponse
+	51, // [51:57] is the sub-list for method output_type
+	45, // [45:51] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_thumbnail_proto_init() }
@@ -404,13 +3737,35 @@ func file_thumbnail_proto_init() {
 	if File_thumbnail_proto != nil {
 		return
 	}
+	file_thumbnail_proto_msgTypes[3].OneofWrappers = []any{
+		(*VideoFrameSelection_Timestamp)(nil),
+		(*VideoFrameSelection_Percentage)(nil),
+		(*VideoFrameSelection_BestInWindow)(nil),
+	}
+	file_thumbnail_proto_msgTypes[12].OneofWrappers = []any{
+		(*ThumbnailStreamRequest_Metadata)(nil),
+		(*ThumbnailStreamRequest_Chunk)(nil),
+	}
+	file_thumbnail_proto_msgTypes[22].OneofWrappers = []any{
+		(*OCRFileChunk_TextContent)(nil),
+		(*OCRFileChunk_OcrContent)(nil),
+		(*OCRFileChunk_Summary)(nil),
+		(*OCRFileChunk_Page)(nil),
+		(*OCRFileChunk_LayoutContent)(nil),
+		(*OCRFileChunk_Layout)(nil),
+	}
+	file_thumbnail_proto_msgTypes[29].OneofWrappers = []any{
+		(*FileInfoResponse_Image)(nil),
+		(*FileInfoResponse_Pdf)(nil),
+		(*FileInfoResponse_Video)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_thumbnail_proto_rawDesc), len(file_thumbnail_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   4,
+			NumEnums:      8,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ThumbnailService_GenerateThumbnail_FullMethodName = "/thumbnail_service.ThumbnailService/GenerateThumbnail"
-	ThumbnailService_OcrFile_FullMethodName           = "/thumbnail_service.ThumbnailService/OcrFile"
+	ThumbnailService_GenerateThumbnail_FullMethodName       = "/thumbnail_service.ThumbnailService/GenerateThumbnail"
+	ThumbnailService_GenerateThumbnailStream_FullMethodName = "/thumbnail_service.ThumbnailService/GenerateThumbnailStream"
+	ThumbnailService_GenerateThumbnails_FullMethodName      = "/thumbnail_service.ThumbnailService/GenerateThumbnails"
+	ThumbnailService_OcrFile_FullMethodName                 = "/thumbnail_service.ThumbnailService/OcrFile"
+	ThumbnailService_OcrFileStream_FullMethodName           = "/thumbnail_service.ThumbnailService/OcrFileStream"
+	ThumbnailService_GetFileInfo_FullMethodName             = "/thumbnail_service.ThumbnailService/GetFileInfo"
 )
 
 // ThumbnailServiceClient is the client API for ThumbnailService service.
@@ -32,9 +36,26 @@ type ThumbnailServiceClient interface {
 	// Generates a thumbnail image from a given file.
 	// Accepts a ThumbnailRequest and returns a ThumbnailResponse.
 	GenerateThumbnail(ctx context.Context, in *ThumbnailRequest, opts ...grpc.CallOption) (*ThumbnailResponse, error)
+	// Generates a thumbnail from a file uploaded as a stream of chunks.
+	// The first message must carry the ThumbnailRequest metadata, every
+	// following message carries the next chunk of the file. Use this instead
+	// of GenerateThumbnail for large inputs such as videos.
+	GenerateThumbnailStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ThumbnailStreamRequest, ThumbnailResponse], error)
+	// Generates thumbnails for several files in one call.
+	// Items are processed concurrently and a failing item does not fail the
+	// whole batch; every item gets its own result.
+	GenerateThumbnails(ctx context.Context, in *ThumbnailBatchRequest, opts ...grpc.CallOption) (*ThumbnailBatchResponse, error)
 	// Performs OCR (Optical Character Recognition) on a provided file.
 	// Accepts an OCRFileRequest and returns an OCRFileResponse.
 	OcrFile(ctx context.Context, in *OCRFileRequest, opts ...grpc.CallOption) (*OCRFileResponse, error)
+	// Performs OCR on a provided file and streams the result back in chunks.
+	// The extracted text, the pages and the layout are sent first, followed
+	// by the OCR processed file, and the stream ends with a single summary
+	// message.
+	OcrFileStream(ctx context.Context, in *OCRFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OCRFileChunk], error)
+	// Returns metadata of a file without generating a thumbnail.
+	// Accepts a FileInfoRequest and returns a FileInfoResponse.
+	GetFileInfo(ctx context.Context, in *FileInfoRequest, opts ...grpc.CallOption) (*FileInfoResponse, error)
 }
 
 type thumbnailServiceClient struct {
@@ -55,6 +76,29 @@ func (c *thumbnailServiceClient) GenerateThumbnail(ctx context.Context, in *Thum
 	return out, nil
 }
 
+func (c *thumbnailServiceClient) GenerateThumbnailStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ThumbnailStreamRequest, ThumbnailResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ThumbnailService_ServiceDesc.Streams[0], ThumbnailService_GenerateThumbnailStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ThumbnailStreamRequest, ThumbnailResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ThumbnailService_GenerateThumbnailStreamClient = grpc.ClientStreamingClient[ThumbnailStreamRequest, ThumbnailResponse]
+
+func (c *thumbnailServiceClient) GenerateThumbnails(ctx context.Context, in *ThumbnailBatchRequest, opts ...grpc.CallOption) (*ThumbnailBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ThumbnailBatchResponse)
+	err := c.cc.Invoke(ctx, ThumbnailService_GenerateThumbnails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *thumbnailServiceClient) OcrFile(ctx context.Context, in *OCRFileRequest, opts ...grpc.CallOption) (*OCRFileResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OCRFileResponse)
@@ -65,6 +109,35 @@ func (c *thumbnailServiceClient) OcrFile(ctx context.Context, in *OCRFileRequest
 	return out, nil
 }
 
+func (c *thumbnailServiceClient) OcrFileStream(ctx context.Context, in *OCRFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OCRFileChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ThumbnailService_ServiceDesc.Streams[1], ThumbnailService_OcrFileStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[OCRFileRequest, OCRFileChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ThumbnailService_OcrFileStreamClient = grpc.ServerStreamingClient[OCRFileChunk]
+
+func (c *thumbnailServiceClient) GetFileInfo(ctx context.Context, in *FileInfoRequest, opts ...grpc.CallOption) (*FileInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileInfoResponse)
+	err := c.cc.Invoke(ctx, ThumbnailService_GetFileInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ThumbnailServiceServer is the server API for ThumbnailService service.
 // All implementations must embed UnimplementedThumbnailServiceServer
 // for forward compatibility.
@@ -74,9 +147,26 @@ type ThumbnailServiceServer interface {
 	// Generates a thumbnail image from a given file.
 	// Accepts a ThumbnailRequest and returns a ThumbnailResponse.
 	GenerateThumbnail(context.Context, *ThumbnailRequest) (*ThumbnailResponse, error)
+	// Generates a thumbnail from a file uploaded as a stream of chunks.
+	// The first message must carry the ThumbnailRequest metadata, every
+	// following message carries the next chunk of the file. Use this instead
+	// of GenerateThumbnail for large inputs such as videos.
+	GenerateThumbnailStream(grpc.ClientStreamingServer[ThumbnailStreamRequest, ThumbnailResponse]) error
+	// Generates thumbnails for several files in one call.
+	// Items are processed concurrently and a failing item does not fail the
+	// whole batch; every item gets its own result.
+	GenerateThumbnails(context.Context, *ThumbnailBatchRequest) (*ThumbnailBatchResponse, error)
 	// Performs OCR (Optical Character Recognition) on a provided file.
 	// Accepts an OCRFileRequest and returns an OCRFileResponse.
 	OcrFile(context.Context, *OCRFileRequest) (*OCRFileResponse, error)
+	// Performs OCR on a provided file and streams the result back in chunks.
+	// The extracted text, the pages and the layout are sent first, followed
+	// by the OCR processed file, and the stream ends with a single summary
+	// message.
+	OcrFileStream(*OCRFileRequest, grpc.ServerStreamingServer[OCRFileChunk]) error
+	// Returns metadata of a file without generating a thumbnail.
+	// Accepts a FileInfoRequest and returns a FileInfoResponse.
+	GetFileInfo(context.Context, *FileInfoRequest) (*FileInfoResponse, error)
 	mustEmbedUnimplementedThumbnailServiceServer()
 }
 
@@ -90,9 +180,21 @@ type UnimplementedThumbnailServiceServer struct{}
 func (UnimplementedThumbnailServiceServer) GenerateThumbnail(context.Context, *ThumbnailRequest) (*ThumbnailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateThumbnail not implemented")
 }
+func (UnimplementedThumbnailServiceServer) GenerateThumbnailStream(grpc.ClientStreamingServer[ThumbnailStreamRequest, ThumbnailResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateThumbnailStream not implemented")
+}
+func (UnimplementedThumbnailServiceServer) GenerateThumbnails(context.Context, *ThumbnailBatchRequest) (*ThumbnailBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateThumbnails not implemented")
+}
 func (UnimplementedThumbnailServiceServer) OcrFile(context.Context, *OCRFileRequest) (*OCRFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method OcrFile not implemented")
 }
+func (UnimplementedThumbnailServiceServer) OcrFileStream(*OCRFileRequest, grpc.ServerStreamingServer[OCRFileChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method OcrFileStream not implemented")
+}
+func (UnimplementedThumbnailServiceServer) GetFileInfo(context.Context, *FileInfoRequest) (*FileInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileInfo not implemented")
+}
 func (UnimplementedThumbnailServiceServer) mustEmbedUnimplementedThumbnailServiceServer() {}
 func (UnimplementedThumbnailServiceServer) testEmbeddedByValue()                          {}
 
@@ -132,6 +234,31 @@ func _ThumbnailService_GenerateThumbnail_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ThumbnailService_GenerateThumbnailStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ThumbnailServiceServer).GenerateThumbnailStream(&grpc.GenericServerStream[ThumbnailStreamRequest, ThumbnailResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ThumbnailService_GenerateThumbnailStreamServer = grpc.ClientStreamingServer[ThumbnailStreamRequest, ThumbnailResponse]
+
+func _ThumbnailService_GenerateThumbnails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThumbnailBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThumbnailServiceServer).GenerateThumbnails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThumbnailService_GenerateThumbnails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThumbnailServiceServer).GenerateThumbnails(ctx, req.(*ThumbnailBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ThumbnailService_OcrFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(OCRFileRequest)
 	if err := dec(in); err != nil {
@@ -150,6 +277,35 @@ func _ThumbnailService_OcrFile_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ThumbnailService_OcrFileStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OCRFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ThumbnailServiceServer).OcrFileStream(m, &grpc.GenericServerStream[OCRFileRequest, OCRFileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ThumbnailService_OcrFileStreamServer = grpc.ServerStreamingServer[OCRFileChunk]
+
+func _ThumbnailService_GetFileInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThumbnailServiceServer).GetFileInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThumbnailService_GetFileInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThumbnailServiceServer).GetFileInfo(ctx, req.(*FileInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ThumbnailService_ServiceDesc is the grpc.ServiceDesc for ThumbnailService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,11 +317,30 @@ var ThumbnailService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateThumbnail",
 			Handler:    _ThumbnailService_GenerateThumbnail_Handler,
 		},
+		{
+			MethodName: "GenerateThumbnails",
+			Handler:    _ThumbnailService_GenerateThumbnails_Handler,
+		},
 		{
 			MethodName: "OcrFile",
 			Handler:    _ThumbnailService_OcrFile_Handler,
 		},
+		{
+			MethodName: "GetFileInfo",
+			Handler:    _ThumbnailService_GetFileInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateThumbnailStream",
+			Handler:       _ThumbnailService_GenerateThumbnailStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "OcrFileStream",
+			Handler:       _ThumbnailService_OcrFileStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "thumbnail.proto",
 }
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"io"
+	"os"
+)
+
+// exifOrientationTag is the TIFF tag holding the EXIF orientation.
+const exifOrientationTag = 0x0112
+
+// jpegOrientation returns the EXIF orientation (1-8) of the JPEG at path, or
+// 0 if the file is not a JPEG or carries no orientation.
+func jpegOrientation(path string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	var marker [2]byte
+	if _, err := io.ReadFull(file, marker[:]); err != nil || marker != [2]byte{0xFF, 0xD8} {
+		return 0
+	}
+
+	// Walk the segments until the APP1 Exif segment or the image data.
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(file, header[:]); err != nil || header[0] != 0xFF {
+			return 0
+		}
+		size := int(binary.BigEndian.Uint16(header[2:])) - 2
+		if size < 0 {
+			return 0
+		}
+
+		switch header[1] {
+		case 0xE1:
+			segment := make([]byte, size)
+			if _, err := io.ReadFull(file, segment); err != nil {
+				return 0
+			}
+			if bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+				return exifOrientation(segment[6:])
+			}
+		case 0xDA, 0xD9:
+			return 0
+		default:
+			if _, err := file.Seek(int64(size), io.SeekCurrent); err != nil {
+				return 0
+			}
+		}
+	}
+}
+
+// exifOrientation reads the orientation tag from the first IFD of a TIFF
+// structured EXIF block.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+
+	entries := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < entries; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+
+		orientation := int(order.Uint16(tiff[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return 0
+		}
+		return orientation
+	}
+
+	return 0
+}
+
+// applyOrientation rotates and flips img so that an image with the given
+// EXIF orientation is displayed upright.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	src := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = w-1-x, y
+			case 3: // rotated 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // mirrored along the top-left diagonal
+				dx, dy = y, x
+			case 6: // rotate 90° clockwise
+				dx, dy = h-1-y, x
+			case 7: // mirrored along the top-right diagonal
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotate 90° counter-clockwise
+				dx, dy = y, w-1-x
+			}
+			si := src.PixOffset(x, y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+
+	return dst
+}
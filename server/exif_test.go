@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// exifBlock builds a TIFF structured EXIF block whose first IFD holds one
+// entry per tag, each with a SHORT value.
+func exifBlock(order binary.AppendByteOrder, tags map[uint16]uint16) []byte {
+	block := []byte("II*\x00\x08\x00\x00\x00")
+	if order == binary.BigEndian {
+		block = []byte("MM\x00*\x00\x00\x00\x08")
+	}
+	block = order.AppendUint16(block, uint16(len(tags)))
+	for tag, value := range tags {
+		block = order.AppendUint16(block, tag)
+		block = order.AppendUint16(block, 3) // SHORT
+		block = order.AppendUint32(block, 1)
+		block = order.AppendUint16(block, value)
+		block = append(block, 0, 0)
+	}
+	return block
+}
+
+func TestExifOrientation(t *testing.T) {
+	tests := []struct {
+		name  string
+		block []byte
+		want  int
+	}{
+		{"little endian", exifBlock(binary.LittleEndian, map[uint16]uint16{exifOrientationTag: 6}), 6},
+		{"big endian", exifBlock(binary.BigEndian, map[uint16]uint16{exifOrientationTag: 3}), 3},
+		{"other tags only", exifBlock(binary.LittleEndian, map[uint16]uint16{0x010F: 1}), 0},
+		{"orientation out of range", exifBlock(binary.LittleEndian, map[uint16]uint16{exifOrientationTag: 9}), 0},
+		{"orientation zero", exifBlock(binary.LittleEndian, map[uint16]uint16{exifOrientationTag: 0}), 0},
+		{"too short", []byte("II*\x00"), 0},
+		{"unknown byte order", []byte("XX*\x00\x08\x00\x00\x00\x00\x00"), 0},
+		{"offset past the end", []byte("II*\x00\xff\x00\x00\x00\x00\x00"), 0},
+		{"offset inside the header", []byte("II*\x00\x02\x00\x00\x00\x00\x00"), 0},
+		{"entries past the end", exifBlock(binary.LittleEndian, map[uint16]uint16{exifOrientationTag: 6})[:20], 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exifOrientation(tt.block); got != tt.want {
+				t.Errorf("exifOrientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// letterImage builds an image with one pixel per letter, the letter is
+// stored in the red channel.
+func letterImage(rows []string) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, len(rows[0]), len(rows)))
+	for y, row := range rows {
+		for x := range row {
+			img.SetNRGBA(x, y, color.NRGBA{R: row[x], A: 0xff})
+		}
+	}
+	return img
+}
+
+// imageLetters reads back the letters of an image built by letterImage.
+func imageLetters(img image.Image) []string {
+	b := img.Bounds()
+	rows := make([]string, 0, b.Dy())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := make([]byte, 0, b.Dx())
+		for x := b.Min.X; x < b.Max.X; x++ {
+			row = append(row, color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA).R)
+		}
+		rows = append(rows, string(row))
+	}
+	return rows
+}
+
+func TestApplyOrientation(t *testing.T) {
+	stored := []string{"ABC", "DEF"}
+
+	tests := []struct {
+		orientation int
+		want        []string
+	}{
+		{0, []string{"ABC", "DEF"}},
+		{1, []string{"ABC", "DEF"}},
+		{2, []string{"CBA", "FED"}},
+		{3, []string{"FED", "CBA"}},
+		{4, []string{"DEF", "ABC"}},
+		{5, []string{"AD", "BE", "CF"}},
+		{6, []string{"DA", "EB", "FC"}},
+		{7, []string{"FC", "EB", "DA"}},
+		{8, []string{"CF", "BE", "AD"}},
+		{9, []string{"ABC", "DEF"}},
+	}
+
+	for _, tt := range tests {
+		got := imageLetters(applyOrientation(letterImage(stored), tt.orientation))
+		if len(got) != len(tt.want) {
+			t.Errorf("applyOrientation(%d) = %q, want %q", tt.orientation, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("applyOrientation(%d) = %q, want %q", tt.orientation, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	pb "github.com/JuLi0n21/thumbnail_service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	tsPacketSize = 188
+	tsPackets    = 5
+
+	// sniffLen covers the header of every sniffed format, including
+	// tsPackets transport stream packets.
+	sniffLen = tsPackets * tsPacketSize
+)
+
+// detectFileType sniffs the content of the file at path and returns the
+// matching file type. Magic bytes are checked first, files that are still
+// unknown are probed with ffprobe for a video stream.
+func detectFileType(path string) (pb.FileType, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return pb.FileType_FILE_TYPE_UNSPECIFIED, fmt.Errorf("failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	header := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return pb.FileType_FILE_TYPE_UNSPECIFIED, fmt.Errorf("failed to read file: %v", err)
+	}
+	header = header[:n]
+
+	fileType, err := sniffFileType(header)
+	if err != nil {
+		return pb.FileType_FILE_TYPE_UNSPECIFIED, err
+	}
+	if fileType != pb.FileType_FILE_TYPE_UNSPECIFIED {
+		return fileType, nil
+	}
+
+	if hasVideoStream(path) {
+		return pb.FileType_VIDEO, nil
+	}
+
+	return pb.FileType_FILE_TYPE_UNSPECIFIED, fmt.Errorf("unable to detect file type of content %q", http.DetectContentType(header))
+}
+
+// sniffFileType classifies a file by its first bytes. Formats that are
+// recognized but can't be processed, like HEIC images or audio files, fail
+// with InvalidArgument instead of a decode error later on.
+func sniffFileType(header []byte) (pb.FileType, error) {
+	switch {
+	case bytes.HasPrefix(header, []byte("II*\x00")), bytes.HasPrefix(header, []byte("MM\x00*")):
+		return pb.FileType_IMAGE, nil // TIFF
+	case len(header) >= 12 && string(header[4:8]) == "ftyp":
+		// ISO base media files share the container, the brand tells
+		// still images and audio apart from videos.
+		switch brand := string(header[8:12]); brand {
+		case "avif", "avis", "heic", "heix", "mif1", "msf1":
+			return pb.FileType_FILE_TYPE_UNSPECIFIED, status.Errorf(codes.InvalidArgument, "unsupported image format %q", brand)
+		case "M4A ", "M4B ", "M4P ", "F4A ", "F4B ":
+			return pb.FileType_FILE_TYPE_UNSPECIFIED, status.Errorf(codes.InvalidArgument, "unsupported audio format %q", brand)
+		}
+		return pb.FileType_VIDEO, nil
+	case isTransportStream(header):
+		return pb.FileType_VIDEO, nil
+	}
+
+	contentType := http.DetectContentType(header)
+	switch contentType {
+	case "application/pdf":
+		return pb.FileType_PDF, nil
+	case "image/jpeg", "image/png", "image/gif", "image/webp", "image/bmp":
+		return pb.FileType_IMAGE, nil
+	}
+	switch {
+	case strings.HasPrefix(contentType, "image/"):
+		return pb.FileType_FILE_TYPE_UNSPECIFIED, status.Errorf(codes.InvalidArgument, "unsupported image format %q", contentType)
+	case strings.HasPrefix(contentType, "video/"):
+		return pb.FileType_VIDEO, nil
+	}
+
+	return pb.FileType_FILE_TYPE_UNSPECIFIED, nil
+}
+
+// isTransportStream reports whether header starts with tsPackets MPEG
+// transport stream packets, each beginning with the 0x47 sync byte.
+func isTransportStream(header []byte) bool {
+	if len(header) < tsPackets*tsPacketSize {
+		return false
+	}
+	for i := 0; i < tsPackets; i++ {
+		if header[i*tsPacketSize] != 0x47 {
+			return false
+		}
+	}
+	return true
+}
+
+// hasVideoStream reports whether ffprobe finds a video stream in the file at
+// path, for containers sniffFileType doesn't recognize.
+func hasVideoStream(path string) bool {
+	cmd := exec.Command("ffprobe", "-v", "error",
+		"-select_streams", "v:0",
+		"-show_entries", "stream=codec_type",
+		"-of", "csv=p=0",
+		path)
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(output)) == "video"
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	pb "github.com/JuLi0n21/thumbnail_service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ftypHeader returns the start of an ISO base media file with the given
+// major brand.
+func ftypHeader(brand string) []byte {
+	return append([]byte{0, 0, 0, 0x18}, "ftyp"+brand+"\x00\x00\x00\x00"...)
+}
+
+// transportStream returns packets MPEG-TS packets starting with sync bytes.
+func transportStream(packets int) []byte {
+	header := make([]byte, packets*tsPacketSize)
+	for i := 0; i < packets; i++ {
+		header[i*tsPacketSize] = 0x47
+	}
+	return header
+}
+
+func TestSniffFileType(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []byte
+		want   pb.FileType
+		code   codes.Code
+	}{
+		{"pdf", []byte("%PDF-1.7\n"), pb.FileType_PDF, codes.OK},
+		{"png", []byte("\x89PNG\r\n\x1a\n"), pb.FileType_IMAGE, codes.OK},
+		{"jpeg", []byte("\xff\xd8\xff\xe0"), pb.FileType_IMAGE, codes.OK},
+		{"bmp", []byte("BM\x00\x00\x00\x00"), pb.FileType_IMAGE, codes.OK},
+		{"tiff little endian", []byte("II*\x00"), pb.FileType_IMAGE, codes.OK},
+		{"tiff big endian", []byte("MM\x00*"), pb.FileType_IMAGE, codes.OK},
+		{"mp4", ftypHeader("isom"), pb.FileType_VIDEO, codes.OK},
+		{"quicktime", ftypHeader("qt  "), pb.FileType_VIDEO, codes.OK},
+		{"heic", ftypHeader("heic"), pb.FileType_FILE_TYPE_UNSPECIFIED, codes.InvalidArgument},
+		{"avif", ftypHeader("avif"), pb.FileType_FILE_TYPE_UNSPECIFIED, codes.InvalidArgument},
+		{"m4a", ftypHeader("M4A "), pb.FileType_FILE_TYPE_UNSPECIFIED, codes.InvalidArgument},
+		{"transport stream", transportStream(tsPackets), pb.FileType_VIDEO, codes.OK},
+		{"too few sync bytes", transportStream(2), pb.FileType_FILE_TYPE_UNSPECIFIED, codes.OK},
+		{"text", []byte("hello world"), pb.FileType_FILE_TYPE_UNSPECIFIED, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sniffFileType(tt.header)
+			if got != tt.want || status.Code(err) != tt.code {
+				t.Errorf("sniffFileType() = %v, %v, want %v with code %v", got, err, tt.want, tt.code)
+			}
+		})
+	}
+}
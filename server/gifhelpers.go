@@ -0,0 +1,273 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"os"
+	"sort"
+)
+
+// paletteSampleLimit caps the number of pixels the palette is computed from.
+const paletteSampleLimit = 200000
+
+// maxAnimationPixels caps the canvas pixels summed over all frames of a GIF
+// that stays animated. Every frame is composited into its own full-size RGBA
+// canvas, larger animations only keep their first frame.
+const maxAnimationPixels = 64 * 1024 * 1024
+
+// encodeAnimatedGif writes full-size frames as a GIF. delays are the
+// per-frame delays in 100ths of a second, a loopCount of 0 loops forever.
+// All frames are dithered against a single palette computed from all of
+// them, which gets a transparent entry if any frame has transparent pixels.
+func encodeAnimatedGif(outputPath string, frames []image.Image, delays []int, loopCount int) error {
+	transparent := hasTransparency(frames)
+	disposal := byte(gif.DisposalNone)
+
+	var palette color.Palette
+	if transparent {
+		// Every frame covers the whole canvas, clearing it between frames
+		// keeps transparent areas from showing the previous frame.
+		palette = append(medianCutPalette(frames, 255), color.Transparent)
+		disposal = gif.DisposalBackground
+	} else {
+		palette = medianCutPalette(frames, 256)
+	}
+
+	anim := &gif.GIF{LoopCount: loopCount}
+	for i, frame := range frames {
+		bounds := frame.Bounds()
+		paletted := image.NewPaletted(image.Rect(0, 0, bounds.Dx(), bounds.Dy()), palette)
+		draw.FloydSteinberg.Draw(paletted, paletted.Bounds(), frame, bounds.Min)
+
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, delays[i])
+		anim.Disposal = append(anim.Disposal, disposal)
+	}
+
+	outFile, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %v", err)
+	}
+	defer outFile.Close()
+
+	if err := gif.EncodeAll(outFile, anim); err != nil {
+		return fmt.Errorf("failed to save animated gif: %v", err)
+	}
+
+	return outFile.Close()
+}
+
+// decodeAnimatedGif decodes all frames of the GIF at path.
+func decodeAnimatedGif(path string) (*gif.GIF, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return gif.DecodeAll(file)
+}
+
+// resizeAnimatedGif fits every frame of the animated GIF anim into maxWidth
+// and maxHeight and writes the result to outputPath, keeping the frame delays
+// and loop count. It returns the region of the frames that was cropped.
+func resizeAnimatedGif(anim *gif.GIF, outputPath string, maxWidth, maxHeight int, opts imageOptions) (image.Rectangle, error) {
+	frames := compositeGifFrames(anim)
+
+	resized := make([]image.Image, 0, len(frames))
+	for _, frame := range frames {
+		// The crop of the first frame is reused for all others.
+		img, crop := fitImage(frame, maxWidth, maxHeight, opts)
+		opts.crop = crop
+		resized = append(resized, img)
+	}
+
+	delays := make([]int, len(frames))
+	copy(delays, anim.Delay)
+
+	return opts.crop, encodeAnimatedGif(outputPath, resized, delays, anim.LoopCount)
+}
+
+// compositeGifFrames renders every frame of anim onto the full canvas the way
+// a viewer displays it, honouring the disposal method of the previous frame.
+func compositeGifFrames(anim *gif.GIF) []image.Image {
+	canvas := image.NewNRGBA(gifCanvas(anim))
+	frames := make([]image.Image, 0, len(anim.Image))
+	for i, frame := range anim.Image {
+		disposal := byte(gif.DisposalNone)
+		if i < len(anim.Disposal) {
+			disposal = anim.Disposal[i]
+		}
+
+		var previous *image.NRGBA
+		if disposal == gif.DisposalPrevious {
+			previous = cloneNRGBA(canvas)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		frames = append(frames, cloneNRGBA(canvas))
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+
+	return frames
+}
+
+// gifCanvas returns the logical screen of anim, or the area covered by its
+// frames if the GIF doesn't declare one.
+func gifCanvas(anim *gif.GIF) image.Rectangle {
+	bounds := image.Rect(0, 0, anim.Config.Width, anim.Config.Height)
+	if bounds.Empty() {
+		for _, frame := range anim.Image {
+			bounds = bounds.Union(frame.Bounds())
+		}
+	}
+	return bounds
+}
+
+// fitsAnimationLimit reports whether the composited frames of anim stay
+// within maxAnimationPixels.
+func fitsAnimationLimit(anim *gif.GIF) bool {
+	canvas := gifCanvas(anim)
+	return canvas.Dx()*canvas.Dy()*len(anim.Image) <= maxAnimationPixels
+}
+
+func cloneNRGBA(img *image.NRGBA) *image.NRGBA {
+	clone := image.NewNRGBA(img.Rect)
+	copy(clone.Pix, img.Pix)
+	return clone
+}
+
+func hasTransparency(images []image.Image) bool {
+	for _, img := range images {
+		if o, ok := img.(interface{ Opaque() bool }); ok && o.Opaque() {
+			continue
+		}
+		b := img.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if _, _, _, a := img.At(x, y).RGBA(); a < 0x8000 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// medianCutPalette computes a palette of at most size colors that represents
+// the opaque pixels of all images, using the median cut algorithm.
+func medianCutPalette(images []image.Image, size int) color.Palette {
+	total := 0
+	for _, img := range images {
+		total += img.Bounds().Dx() * img.Bounds().Dy()
+	}
+	step := max(1, total/paletteSampleLimit)
+
+	pixels := make([][3]uint8, 0, min(total, paletteSampleLimit+len(images)))
+	n := 0
+	for _, img := range images {
+		b := img.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				n++
+				if n%step != 0 {
+					continue
+				}
+				c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+				if c.A < 0x80 {
+					continue
+				}
+				pixels = append(pixels, [3]uint8{c.R, c.G, c.B})
+			}
+		}
+	}
+	if len(pixels) == 0 {
+		return color.Palette{color.Black}
+	}
+
+	boxes := []colorBox{newColorBox(pixels)}
+	for len(boxes) < size {
+		// Split the box with the widest channel range, weighted by how many
+		// pixels it covers, that can still be split.
+		best, bestScore := -1, 0
+		for i, box := range boxes {
+			if score := box.spread() * len(box.pixels); len(box.pixels) > 1 && score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		a, b := boxes[best].split()
+		boxes[best] = a
+		boxes = append(boxes, b)
+	}
+
+	palette := make(color.Palette, 0, len(boxes))
+	for _, box := range boxes {
+		palette = append(palette, box.average())
+	}
+	return palette
+}
+
+type colorBox struct {
+	pixels   [][3]uint8
+	min, max [3]uint8
+}
+
+func newColorBox(pixels [][3]uint8) colorBox {
+	box := colorBox{pixels: pixels, min: [3]uint8{255, 255, 255}}
+	for _, p := range pixels {
+		for c := 0; c < 3; c++ {
+			box.min[c] = min(box.min[c], p[c])
+			box.max[c] = max(box.max[c], p[c])
+		}
+	}
+	return box
+}
+
+// widest returns the channel with the largest range.
+func (b colorBox) widest() int {
+	channel := 0
+	for c := 1; c < 3; c++ {
+		if b.max[c]-b.min[c] > b.max[channel]-b.min[channel] {
+			channel = c
+		}
+	}
+	return channel
+}
+
+func (b colorBox) spread() int {
+	c := b.widest()
+	return int(b.max[c] - b.min[c])
+}
+
+// split divides the box at the median of its widest channel.
+func (b colorBox) split() (colorBox, colorBox) {
+	c := b.widest()
+	sort.Slice(b.pixels, func(i, j int) bool { return b.pixels[i][c] < b.pixels[j][c] })
+
+	median := len(b.pixels) / 2
+	return newColorBox(b.pixels[:median]), newColorBox(b.pixels[median:])
+}
+
+func (b colorBox) average() color.Color {
+	var sum [3]int
+	for _, p := range b.pixels {
+		for c := 0; c < 3; c++ {
+			sum[c] += int(p[c])
+		}
+	}
+	n := len(b.pixels)
+	return color.RGBA{R: uint8(sum[0] / n), G: uint8(sum[1] / n), B: uint8(sum[2] / n), A: 255}
+}
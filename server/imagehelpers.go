@@ -0,0 +1,448 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	pb "github.com/JuLi0n21/thumbnail_service/proto"
+	"github.com/nfnt/resize"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// imageOptions controls how resizeImage fits and encodes a thumbnail.
+type imageOptions struct {
+	format         pb.OutputFormat
+	quality        int
+	pngCompression pb.PngCompression
+	fitMode        pb.FitMode
+	background     color.Color
+	focalPoint     *pb.FocalPoint
+	firstFrameOnly bool
+	orientation    int
+	// crop reuses a region computed for an earlier frame of an animation so
+	// all frames are cropped the same way.
+	crop image.Rectangle
+}
+
+func newImageOptions(req *pb.ThumbnailRequest) (imageOptions, error) {
+	if req.Quality < 0 || req.Quality > 100 {
+		return imageOptions{}, status.Errorf(codes.InvalidArgument, "quality must be between 1 and 100, got %d", req.Quality)
+	}
+
+	if f := req.FocalPoint; f != nil && (f.X < 0 || f.X > 1 || f.Y < 0 || f.Y > 1) {
+		return imageOptions{}, status.Errorf(codes.InvalidArgument, "focal point must be between 0 and 1, got (%v, %v)", f.X, f.Y)
+	}
+
+	background := color.Color(color.Black)
+	if req.BackgroundColor != "" {
+		c, err := parseHexColor(req.BackgroundColor)
+		if err != nil {
+			return imageOptions{}, err
+		}
+		background = c
+	}
+
+	return imageOptions{
+		format:         req.OutputFormat,
+		quality:        int(req.Quality),
+		pngCompression: req.PngCompression,
+		fitMode:        req.FitMode,
+		background:     background,
+		focalPoint:     req.FocalPoint,
+		firstFrameOnly: req.FirstFrameOnly,
+	}, nil
+}
+
+// parseHexColor parses colors in the #RGB, #RRGGBB and #RRGGBBAA notation,
+// the leading # is optional.
+func parseHexColor(s string) (color.NRGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 8 || err != nil {
+		return color.NRGBA{}, status.Errorf(codes.InvalidArgument, "invalid color %q, expected #RRGGBB or #RRGGBBAA", s)
+	}
+
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+// fitImage scales img to the requested maximum size. If only one of
+// maxWidth and maxHeight is set the aspect ratio is kept, if both are set
+// opts.fitMode decides how the image is fitted into the box. The returned
+// rectangle is the region of img that was cropped, it is empty if the whole
+// image was used.
+func fitImage(img image.Image, maxWidth, maxHeight int, opts imageOptions) (image.Image, image.Rectangle) {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+
+	switch {
+	case maxWidth > 0 && maxHeight > 0:
+	case maxWidth > 0:
+		return resize.Resize(uint(maxWidth), uint(scaleDimension(height, maxWidth, width)), img, resize.Lanczos3), image.Rectangle{}
+	case maxHeight > 0:
+		return resize.Resize(uint(scaleDimension(width, maxHeight, height)), uint(maxHeight), img, resize.Lanczos3), image.Rectangle{}
+	default:
+		return resize.Resize(uint(width), uint(height), img, resize.Lanczos3), image.Rectangle{}
+	}
+
+	switch opts.fitMode {
+	case pb.FitMode_CONTAIN:
+		w, h := containSize(width, height, maxWidth, maxHeight)
+		return resize.Resize(uint(w), uint(h), img, resize.Lanczos3), image.Rectangle{}
+	case pb.FitMode_COVER, pb.FitMode_SMART:
+		crop := cropRect(img, maxWidth, maxHeight, opts)
+		return resize.Resize(uint(maxWidth), uint(maxHeight), subImage(img, crop), resize.Lanczos3), crop
+	case pb.FitMode_PAD:
+		w, h := containSize(width, height, maxWidth, maxHeight)
+		scaled := resize.Resize(uint(w), uint(h), img, resize.Lanczos3)
+
+		canvas := image.NewNRGBA(image.Rect(0, 0, maxWidth, maxHeight))
+		draw.Draw(canvas, canvas.Bounds(), image.NewUniform(opts.background), image.Point{}, draw.Src)
+		// resize returns img itself if it already has the target size, and
+		// its bounds don't start at (0, 0) for sub-images, so the scaled
+		// image is placed by its size rather than its bounds.
+		offset := image.Pt((maxWidth-w)/2, (maxHeight-h)/2)
+		target := image.Rectangle{Min: offset, Max: offset.Add(scaled.Bounds().Size())}
+		draw.Draw(canvas, target, scaled, scaled.Bounds().Min, draw.Over)
+		return canvas, image.Rectangle{}
+	default:
+		return resize.Resize(uint(maxWidth), uint(maxHeight), img, resize.Lanczos3), image.Rectangle{}
+	}
+}
+
+// cropRect picks the region of img that COVER and SMART scale into the box.
+// A focal point takes precedence over both the center and the smart crop.
+func cropRect(img image.Image, maxWidth, maxHeight int, opts imageOptions) image.Rectangle {
+	switch {
+	case !opts.crop.Empty():
+		return opts.crop
+	case opts.focalPoint != nil:
+		return focalCrop(img.Bounds(), maxWidth, maxHeight, opts.focalPoint)
+	case opts.fitMode == pb.FitMode_SMART:
+		return smartCrop(img, maxWidth, maxHeight)
+	default:
+		return centerCrop(img.Bounds(), maxWidth, maxHeight)
+	}
+}
+
+// cropRectMessage converts a crop returned by fitImage, nil if the image was
+// not cropped.
+func cropRectMessage(crop image.Rectangle) *pb.CropRect {
+	if crop.Empty() {
+		return nil
+	}
+	return &pb.CropRect{
+		X:      int32(crop.Min.X),
+		Y:      int32(crop.Min.Y),
+		Width:  int32(crop.Dx()),
+		Height: int32(crop.Dy()),
+	}
+}
+
+// scaleDimension scales other by target/source, the result is at least 1.
+func scaleDimension(other, target, source int) int {
+	return max(1, int(float64(other)*float64(target)/float64(source)))
+}
+
+// containSize returns the largest size with the aspect ratio of width and
+// height that fits inside maxWidth and maxHeight.
+func containSize(width, height, maxWidth, maxHeight int) (int, int) {
+	if width*maxHeight > height*maxWidth {
+		return maxWidth, scaleDimension(height, maxWidth, width)
+	}
+	return scaleDimension(width, maxHeight, height), maxHeight
+}
+
+// centerCrop returns the largest rectangle with the aspect ratio of
+// maxWidth and maxHeight centered in bounds.
+func centerCrop(bounds image.Rectangle, maxWidth, maxHeight int) image.Rectangle {
+	width, height := bounds.Dx(), bounds.Dy()
+	cropWidth, cropHeight := width, height
+	if width*maxHeight > height*maxWidth {
+		cropWidth = scaleDimension(height, maxWidth, maxHeight)
+	} else {
+		cropHeight = scaleDimension(width, maxHeight, maxWidth)
+	}
+
+	x := bounds.Min.X + (width-cropWidth)/2
+	y := bounds.Min.Y + (height-cropHeight)/2
+	return image.Rect(x, y, x+cropWidth, y+cropHeight)
+}
+
+// subImage returns the part of img inside rect, copying it only if the image
+// type does not support sub images.
+func subImage(img image.Image, rect image.Rectangle) image.Image {
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(rect)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, rect.Min, draw.Src)
+	return dst
+}
+
+func decodeImageFile(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image file: %v", err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %v", err)
+	}
+	return img, nil
+}
+
+// imageInfo reads the format, dimensions and color model of the image at
+// path without decoding the pixel data, except for counting GIF frames.
+func imageInfo(path string) (*pb.ImageInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image file: %v", err)
+	}
+	defer file.Close()
+
+	config, format, err := image.DecodeConfig(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %v", err)
+	}
+
+	info := &pb.ImageInfo{
+		Format:      format,
+		Width:       int32(config.Width),
+		Height:      int32(config.Height),
+		ColorModel:  colorModelName(config.ColorModel),
+		Orientation: int32(jpegOrientation(path)),
+		FrameCount:  1,
+	}
+
+	if format == "gif" {
+		if anim, err := decodeAnimatedGif(path); err == nil {
+			info.FrameCount = int32(len(anim.Image))
+		}
+	}
+
+	return info, nil
+}
+
+func colorModelName(model color.Model) string {
+	switch model {
+	case color.RGBAModel:
+		return "RGBA"
+	case color.RGBA64Model:
+		return "RGBA64"
+	case color.NRGBAModel:
+		return "NRGBA"
+	case color.NRGBA64Model:
+		return "NRGBA64"
+	case color.AlphaModel:
+		return "Alpha"
+	case color.Alpha16Model:
+		return "Alpha16"
+	case color.GrayModel:
+		return "Gray"
+	case color.Gray16Model:
+		return "Gray16"
+	case color.YCbCrModel:
+		return "YCbCr"
+	case color.NYCbCrAModel:
+		return "NYCbCrA"
+	case color.CMYKModel:
+		return "CMYK"
+	}
+	if _, ok := model.(color.Palette); ok {
+		return "Paletted"
+	}
+	return "unknown"
+}
+
+// tileImages lays out images in a grid of equally sized cells, left to right
+// and top to bottom. Every image is centered in its cell. A columns value of
+// 0 picks a roughly square grid.
+func tileImages(images []image.Image, columns, spacing int, background color.Color) *image.NRGBA {
+	if len(images) == 0 {
+		return image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	}
+	if columns <= 0 {
+		columns = int(math.Ceil(math.Sqrt(float64(len(images)))))
+	}
+	columns = min(columns, len(images))
+	rows := (len(images) + columns - 1) / columns
+
+	cellWidth, cellHeight := 0, 0
+	for _, img := range images {
+		cellWidth = max(cellWidth, img.Bounds().Dx())
+		cellHeight = max(cellHeight, img.Bounds().Dy())
+	}
+
+	width := columns*cellWidth + (columns-1)*spacing
+	height := rows*cellHeight + (rows-1)*spacing
+	canvas := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	for i, img := range images {
+		b := img.Bounds()
+		x := (i%columns)*(cellWidth+spacing) + (cellWidth-b.Dx())/2
+		y := (i/columns)*(cellHeight+spacing) + (cellHeight-b.Dy())/2
+		draw.Draw(canvas, image.Rect(x, y, x+b.Dx(), y+b.Dy()), img, b.Min, draw.Over)
+	}
+
+	return canvas
+}
+
+// resolveOutputFormat returns the format a thumbnail of the image at
+// sourcePath is encoded in when the request leaves it unspecified.
+func resolveOutputFormat(format pb.OutputFormat, sourcePath string) pb.OutputFormat {
+	if format != pb.OutputFormat_OUTPUT_FORMAT_UNSPECIFIED {
+		return format
+	}
+
+	file, err := os.Open(sourcePath)
+	if err != nil {
+		return pb.OutputFormat_JPEG
+	}
+	defer file.Close()
+
+	_, imgType, err := image.DecodeConfig(file)
+	if err != nil {
+		return pb.OutputFormat_JPEG
+	}
+
+	switch imgType {
+	case "png":
+		return pb.OutputFormat_PNG
+	case "gif":
+		return pb.OutputFormat_GIF
+	case "webp":
+		return pb.OutputFormat_WEBP
+	default:
+		return pb.OutputFormat_JPEG
+	}
+}
+
+func mimeType(format pb.OutputFormat) string {
+	switch format {
+	case pb.OutputFormat_PNG:
+		return "image/png"
+	case pb.OutputFormat_GIF:
+		return "image/gif"
+	case pb.OutputFormat_WEBP:
+		return "image/webp"
+	default:
+		return "image/jpeg"
+	}
+}
+
+func fileExtension(format pb.OutputFormat) string {
+	switch format {
+	case pb.OutputFormat_PNG:
+		return ".png"
+	case pb.OutputFormat_GIF:
+		return ".gif"
+	case pb.OutputFormat_WEBP:
+		return ".webp"
+	default:
+		return ".jpg"
+	}
+}
+
+func encodeImage(outputPath string, img image.Image, opts imageOptions) error {
+	if opts.format == pb.OutputFormat_WEBP {
+		return encodeWebp(outputPath, img, opts.quality)
+	}
+
+	outFile, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %v", err)
+	}
+	defer outFile.Close()
+
+	switch opts.format {
+	case pb.OutputFormat_JPEG:
+		quality := jpeg.DefaultQuality
+		if opts.quality > 0 {
+			quality = opts.quality
+		}
+		err = jpeg.Encode(outFile, img, &jpeg.Options{Quality: quality})
+		if err != nil {
+			return fmt.Errorf("failed to save resized image as jpeg: %v", err)
+		}
+	case pb.OutputFormat_PNG:
+		encoder := png.Encoder{CompressionLevel: pngCompressionLevel(opts.pngCompression)}
+		err = encoder.Encode(outFile, img)
+		if err != nil {
+			return fmt.Errorf("failed to save resized image as png: %v", err)
+		}
+	case pb.OutputFormat_GIF:
+		err = gif.Encode(outFile, img, &gif.Options{NumColors: 256})
+		if err != nil {
+			return fmt.Errorf("failed to save resized image as gif: %v", err)
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "unsupported output format: %v", opts.format)
+	}
+
+	return outFile.Close()
+}
+
+func pngCompressionLevel(compression pb.PngCompression) png.CompressionLevel {
+	switch compression {
+	case pb.PngCompression_PNG_COMPRESSION_NONE:
+		return png.NoCompression
+	case pb.PngCompression_PNG_COMPRESSION_BEST_SPEED:
+		return png.BestSpeed
+	case pb.PngCompression_PNG_COMPRESSION_BEST:
+		return png.BestCompression
+	default:
+		return png.DefaultCompression
+	}
+}
+
+// encodeWebp encodes img as WebP. The standard library has no WebP encoder,
+// so the image is piped to FFmpeg as PNG.
+func encodeWebp(outputPath string, img image.Image, quality int) error {
+	var input bytes.Buffer
+	if err := png.Encode(&input, img); err != nil {
+		return fmt.Errorf("failed to prepare image for webp encoding: %v", err)
+	}
+
+	if quality == 0 {
+		quality = 75
+	}
+
+	cmd := exec.Command("ffmpeg", "-y",
+		"-f", "png_pipe", "-i", "pipe:0",
+		"-c:v", "libwebp",
+		"-quality", strconv.Itoa(quality),
+		"-f", "webp", outputPath)
+	cmd.Stdin = &input
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to save resized image as webp using FFmpeg: %v. FFmpeg stderr: %s", err, stderr.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,462 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	pb "github.com/JuLi0n21/thumbnail_service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bboxDocument mirrors the XHTML written by pdftotext -bbox-layout.
+type bboxDocument struct {
+	Pages []bboxPage `xml:"body>doc>page"`
+}
+
+type bboxPage struct {
+	Width  float64    `xml:"width,attr"`
+	Height float64    `xml:"height,attr"`
+	Lines  []bboxLine `xml:"flow>block>line"`
+}
+
+type bboxLine struct {
+	bboxRect
+	Words []bboxWord `xml:"word"`
+}
+
+type bboxWord struct {
+	bboxRect
+	Text string `xml:",chardata"`
+}
+
+type bboxRect struct {
+	XMin float64 `xml:"xMin,attr"`
+	YMin float64 `xml:"yMin,attr"`
+	XMax float64 `xml:"xMax,attr"`
+	YMax float64 `xml:"yMax,attr"`
+}
+
+func (r bboxRect) message() *pb.BoundingBox {
+	return &pb.BoundingBox{XMin: r.XMin, YMin: r.YMin, XMax: r.XMax, YMax: r.YMax}
+}
+
+// extractLayoutFromPDF returns the position of every word in the text layer
+// of the PDF at path, grouped into pages and lines. pdftotext -bbox-layout
+// only knows the text layer, the confidence of OCRed words is added from the
+// hOCR of the OCR by applyHOCRConfidence.
+func extractLayoutFromPDF(path string) ([]*pb.LayoutPage, error) {
+	tmpOut, err := os.CreateTemp("", "pdftotext-*.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpOut.Close()
+	defer os.Remove(tmpOut.Name())
+
+	cmd := exec.Command("pdftotext", "-bbox-layout", path, tmpOut.Name())
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("pdftotext failed: %v\nOutput: %s", err, output)
+	}
+
+	file, err := os.Open(tmpOut.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pdftotext output: %w", err)
+	}
+	defer file.Close()
+
+	var doc bboxDocument
+	decoder := xml.NewDecoder(file)
+	decoder.Entity = xml.HTMLEntity
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse pdftotext output: %v", err)
+	}
+
+	pages := make([]*pb.LayoutPage, 0, len(doc.Pages))
+	for i, page := range doc.Pages {
+		layoutPage := &pb.LayoutPage{
+			PageNumber: int32(i + 1),
+			Width:      page.Width,
+			Height:     page.Height,
+		}
+		for _, line := range page.Lines {
+			layoutLine := &pb.LayoutLine{Bbox: line.message()}
+			for _, word := range line.Words {
+				layoutLine.Words = append(layoutLine.Words, &pb.LayoutWord{
+					Text: word.Text,
+					Bbox: word.message(),
+				})
+			}
+			layoutPage.Lines = append(layoutPage.Lines, layoutLine)
+		}
+		pages = append(pages, layoutPage)
+	}
+
+	return pages, nil
+}
+
+// hocrWord is a word of tesseract's hOCR output. Its bounding box is given as
+// fractions of the page size, so it can be compared with the text layer
+// regardless of the resolution the page was rendered at for the OCR.
+type hocrWord struct {
+	Text       string
+	XMin, YMin float64
+	XMax, YMax float64
+	Confidence float32
+}
+
+// readOCRMyPDFHOCR reads the hOCR files ocrmypdf kept in dir when it ran with
+// --keep-temporary-files, keyed by the 1-based number of the OCRed page.
+func readOCRMyPDFHOCR(dir string) (map[int32][]hocrWord, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "ocrmypdf.io.*", "*_ocr_hocr.hocr"))
+	if err != nil {
+		return nil, err
+	}
+
+	pages := map[int32][]hocrWord{}
+	for _, file := range files {
+		// ocrmypdf names the files of every page after its 1-based number,
+		// e.g. 000001_ocr_hocr.hocr.
+		prefix, _, _ := strings.Cut(filepath.Base(file), "_")
+		page, err := strconv.Atoi(prefix)
+		if err != nil {
+			continue
+		}
+
+		words, err := readHOCRFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read hocr of page %d: %v", page, err)
+		}
+		pages[int32(page)] = words
+	}
+	return pages, nil
+}
+
+func readHOCRFile(path string) ([]hocrWord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseHOCR(file)
+}
+
+// parseHOCR returns the words of a single page hOCR document with their
+// bounding box relative to the ocr_page and their x_wconf confidence.
+func parseHOCR(r io.Reader) ([]hocrWord, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	var pageWidth, pageHeight float64
+	var words []hocrWord
+	var word *hocrWord
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return words, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if word != nil {
+				depth++
+				continue
+			}
+			class, title := "", ""
+			for _, attr := range t.Attr {
+				switch attr.Name.Local {
+				case "class":
+					class = attr.Value
+				case "title":
+					title = attr.Value
+				}
+			}
+			props := hocrProperties(title)
+			box, ok := hocrBBox(props["bbox"])
+			switch {
+			case !ok:
+			case class == "ocr_page":
+				pageWidth, pageHeight = box[2]-box[0], box[3]-box[1]
+			case class == "ocrx_word" && pageWidth > 0 && pageHeight > 0:
+				conf, _ := strconv.ParseFloat(props["x_wconf"], 32)
+				word = &hocrWord{
+					XMin:       box[0] / pageWidth,
+					YMin:       box[1] / pageHeight,
+					XMax:       box[2] / pageWidth,
+					YMax:       box[3] / pageHeight,
+					Confidence: float32(conf),
+				}
+				depth = 0
+			}
+		case xml.CharData:
+			if word != nil {
+				word.Text += string(t)
+			}
+		case xml.EndElement:
+			if word == nil {
+				continue
+			}
+			if depth > 0 {
+				depth--
+				continue
+			}
+			word.Text = strings.TrimSpace(word.Text)
+			words = append(words, *word)
+			word = nil
+		}
+	}
+}
+
+// hocrProperties splits an hOCR title like "bbox 1 2 3 4; x_wconf 96" into
+// its properties.
+func hocrProperties(title string) map[string]string {
+	props := map[string]string{}
+	for _, prop := range strings.Split(title, ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(prop), " ")
+		props[name] = strings.TrimSpace(value)
+	}
+	return props
+}
+
+func hocrBBox(value string) ([4]float64, bool) {
+	var box [4]float64
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return box, false
+	}
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return box, false
+		}
+		box[i] = v
+	}
+	return box, true
+}
+
+// applyHOCRConfidence sets the confidence of the words of OCRed pages to the
+// one tesseract reported for the hOCR word under their center, preferring a
+// word with the same text if boxes overlap. Pages without hOCR keep none.
+func applyHOCRConfidence(pages []*pb.LayoutPage, hocr map[int32][]hocrWord) {
+	for _, page := range pages {
+		words := hocr[page.PageNumber]
+		if len(words) == 0 || page.Width <= 0 || page.Height <= 0 {
+			continue
+		}
+
+		for _, line := range page.Lines {
+			for _, word := range line.Words {
+				x := (word.Bbox.XMin + word.Bbox.XMax) / 2 / page.Width
+				y := (word.Bbox.YMin + word.Bbox.YMax) / 2 / page.Height
+
+				var match *hocrWord
+				for i := range words {
+					w := &words[i]
+					if x < w.XMin || x > w.XMax || y < w.YMin || y > w.YMax {
+						continue
+					}
+					if match == nil || w.Text == word.Text {
+						match = w
+					}
+					if w.Text == word.Text {
+						break
+					}
+				}
+				if match != nil {
+					word.Confidence = match.Confidence
+				}
+			}
+		}
+	}
+}
+
+// formatLayout renders pages in the requested layout format.
+func formatLayout(pages []*pb.LayoutPage, format pb.LayoutFormat) (string, error) {
+	switch format {
+	case pb.LayoutFormat_HOCR:
+		return formatHOCR(pages), nil
+	case pb.LayoutFormat_ALTO:
+		return formatALTO(pages)
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unsupported layout format %v", format)
+}
+
+// formatHOCR renders pages as an hOCR document. hOCR uses integer
+// coordinates, so the bounding boxes are rounded to whole points.
+func formatHOCR(pages []*pb.LayoutPage) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
+<html xmlns="http://www.w3.org/1999/xhtml" xml:lang="en" lang="en">
+<head>
+<title></title>
+<meta http-equiv="Content-Type" content="text/html;charset=utf-8"/>
+<meta name="ocr-system" content="thumbnail-service"/>
+<meta name="ocr-capabilities" content="ocr_page ocr_line ocrx_word"/>
+</head>
+<body>
+`)
+
+	for _, page := range pages {
+		p := page.PageNumber
+		fmt.Fprintf(&b, "<div class=\"ocr_page\" id=\"page_%d\" title=\"bbox 0 0 %d %d; ppageno %d\">\n",
+			p, roundPoints(page.Width), roundPoints(page.Height), p-1)
+		for l, line := range page.Lines {
+			fmt.Fprintf(&b, "<span class=\"ocr_line\" id=\"line_%d_%d\" title=\"%s\">", p, l+1, hocrBox(line.Bbox))
+			for w, word := range line.Words {
+				if w > 0 {
+					b.WriteString(" ")
+				}
+				title := hocrBox(word.Bbox)
+				if word.Confidence > 0 {
+					title += fmt.Sprintf("; x_wconf %d", int(math.Round(float64(word.Confidence))))
+				}
+				fmt.Fprintf(&b, "<span class=\"ocrx_word\" id=\"word_%d_%d_%d\" title=\"%s\">%s</span>",
+					p, l+1, w+1, title, html.EscapeString(word.Text))
+			}
+			b.WriteString("</span>\n")
+		}
+		b.WriteString("</div>\n")
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+func hocrBox(box *pb.BoundingBox) string {
+	return fmt.Sprintf("bbox %d %d %d %d", roundPoints(box.XMin), roundPoints(box.YMin), roundPoints(box.XMax), roundPoints(box.YMax))
+}
+
+func roundPoints(v float64) int {
+	return int(math.Round(v))
+}
+
+type altoDocument struct {
+	XMLName         xml.Name   `xml:"alto"`
+	Xmlns           string     `xml:"xmlns,attr"`
+	MeasurementUnit string     `xml:"Description>MeasurementUnit"`
+	Pages           []altoPage `xml:"Layout>Page"`
+}
+
+type altoPage struct {
+	ID            string         `xml:"ID,attr"`
+	PhysicalImgNr int32          `xml:"PHYSICAL_IMG_NR,attr"`
+	Width         int            `xml:"WIDTH,attr"`
+	Height        int            `xml:"HEIGHT,attr"`
+	PrintSpace    altoPrintSpace `xml:"PrintSpace"`
+}
+
+type altoPrintSpace struct {
+	altoBox
+	Blocks []altoTextBlock `xml:"TextBlock"`
+}
+
+type altoTextBlock struct {
+	ID string `xml:"ID,attr"`
+	altoBox
+	Lines []altoTextLine `xml:"TextLine"`
+}
+
+type altoTextLine struct {
+	ID string `xml:"ID,attr"`
+	altoBox
+	Strings []altoString `xml:"String"`
+}
+
+type altoString struct {
+	ID      string `xml:"ID,attr"`
+	Content string `xml:"CONTENT,attr"`
+	altoBox
+	WC float32 `xml:"WC,attr,omitempty"`
+}
+
+type altoBox struct {
+	HPos   int `xml:"HPOS,attr"`
+	VPos   int `xml:"VPOS,attr"`
+	Width  int `xml:"WIDTH,attr"`
+	Height int `xml:"HEIGHT,attr"`
+}
+
+// altoUnitsPerPoint converts PDF points to the inch1200 measurement unit.
+const altoUnitsPerPoint = 1200.0 / 72.0
+
+func newAltoBox(box *pb.BoundingBox) altoBox {
+	return altoBox{
+		HPos:   altoUnits(box.XMin),
+		VPos:   altoUnits(box.YMin),
+		Width:  altoUnits(box.XMax - box.XMin),
+		Height: altoUnits(box.YMax - box.YMin),
+	}
+}
+
+func altoUnits(points float64) int {
+	return int(math.Round(points * altoUnitsPerPoint))
+}
+
+// formatALTO renders pages as an ALTO v4 document. The text layer carries no
+// blocks, so all lines of a page are put into a single text block.
+func formatALTO(pages []*pb.LayoutPage) (string, error) {
+	doc := altoDocument{
+		Xmlns:           "http://www.loc.gov/standards/alto/ns-v4#",
+		MeasurementUnit: "inch1200",
+	}
+
+	for _, page := range pages {
+		p := page.PageNumber
+		full := &pb.BoundingBox{XMax: page.Width, YMax: page.Height}
+		altoP := altoPage{
+			ID:            fmt.Sprintf("page_%d", p),
+			PhysicalImgNr: p,
+			Width:         altoUnits(page.Width),
+			Height:        altoUnits(page.Height),
+			PrintSpace:    altoPrintSpace{altoBox: newAltoBox(full)},
+		}
+
+		if len(page.Lines) > 0 {
+			first := page.Lines[0].Bbox
+			area := &pb.BoundingBox{XMin: first.XMin, YMin: first.YMin, XMax: first.XMax, YMax: first.YMax}
+			block := altoTextBlock{ID: fmt.Sprintf("block_%d", p)}
+			for l, line := range page.Lines {
+				area.XMin = min(area.XMin, line.Bbox.XMin)
+				area.YMin = min(area.YMin, line.Bbox.YMin)
+				area.XMax = max(area.XMax, line.Bbox.XMax)
+				area.YMax = max(area.YMax, line.Bbox.YMax)
+
+				textLine := altoTextLine{ID: fmt.Sprintf("line_%d_%d", p, l+1), altoBox: newAltoBox(line.Bbox)}
+				for w, word := range line.Words {
+					textLine.Strings = append(textLine.Strings, altoString{
+						ID:      fmt.Sprintf("word_%d_%d_%d", p, l+1, w+1),
+						Content: word.Text,
+						altoBox: newAltoBox(word.Bbox),
+						WC:      word.Confidence / 100,
+					})
+				}
+				block.Lines = append(block.Lines, textLine)
+			}
+			block.altoBox = newAltoBox(area)
+			altoP.PrintSpace.Blocks = append(altoP.PrintSpace.Blocks, block)
+		}
+
+		doc.Pages = append(doc.Pages, altoP)
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode alto: %v", err)
+	}
+	return xml.Header + string(out) + "\n", nil
+}
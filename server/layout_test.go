@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/JuLi0n21/thumbnail_service/proto"
+)
+
+const testHOCR = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
+<html xmlns="http://www.w3.org/1999/xhtml" xml:lang="en" lang="en">
+ <body>
+  <div class='ocr_page' id='page_1' title='image "page.png"; bbox 0 0 2000 1000; ppageno 0'>
+   <span class='ocr_line' id='line_1_1' title="bbox 100 100 900 200; baseline 0 -10">
+    <span class='ocrx_word' id='word_1_1' title='bbox 100 100 400 200; x_wconf 96'>Hello</span>
+    <span class='ocrx_word' id='word_1_2' title='bbox 500 100 900 200; x_wconf 71'><strong>w&amp;rld</strong></span>
+   </span>
+  </div>
+ </body>
+</html>
+`
+
+func TestParseHOCR(t *testing.T) {
+	words, err := parseHOCR(strings.NewReader(testHOCR))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []hocrWord{
+		{Text: "Hello", XMin: 0.05, YMin: 0.1, XMax: 0.2, YMax: 0.2, Confidence: 96},
+		{Text: "w&rld", XMin: 0.25, YMin: 0.1, XMax: 0.45, YMax: 0.2, Confidence: 71},
+	}
+	if len(words) != len(want) {
+		t.Fatalf("parseHOCR() = %+v, want %+v", words, want)
+	}
+	for i := range words {
+		if words[i] != want[i] {
+			t.Errorf("parseHOCR() word %d = %+v, want %+v", i, words[i], want[i])
+		}
+	}
+}
+
+func TestApplyHOCRConfidence(t *testing.T) {
+	word := func(text string, xMin, xMax float64) *pb.LayoutWord {
+		return &pb.LayoutWord{Text: text, Bbox: &pb.BoundingBox{XMin: xMin, YMin: 60, XMax: xMax, YMax: 100}}
+	}
+	pages := []*pb.LayoutPage{
+		{
+			PageNumber: 1,
+			Width:      400,
+			Height:     400,
+			Lines:      []*pb.LayoutLine{{Words: []*pb.LayoutWord{word("Hello", 20, 80), word("w&rld", 100, 180), word("margin", 300, 380)}}},
+		},
+		{
+			PageNumber: 2,
+			Width:      400,
+			Height:     400,
+			Lines:      []*pb.LayoutLine{{Words: []*pb.LayoutWord{word("native", 20, 80)}}},
+		},
+	}
+	hocr := map[int32][]hocrWord{1: {
+		{Text: "Hello", XMin: 0.05, YMin: 0.1, XMax: 0.2, YMax: 0.3, Confidence: 96},
+		{Text: "other", XMin: 0.2, YMin: 0.1, XMax: 0.5, YMax: 0.3, Confidence: 10},
+		{Text: "w&rld", XMin: 0.25, YMin: 0.1, XMax: 0.45, YMax: 0.3, Confidence: 71},
+	}}
+
+	applyHOCRConfidence(pages, hocr)
+
+	want := map[string]float32{"Hello": 96, "w&rld": 71, "margin": 0, "native": 0}
+	for _, page := range pages {
+		for _, w := range page.Lines[0].Words {
+			if w.Confidence != want[w.Text] {
+				t.Errorf("confidence of %q = %v, want %v", w.Text, w.Confidence, want[w.Text])
+			}
+		}
+	}
+}
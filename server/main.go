@@ -1,195 +1,609 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"image"
+	"image/color"
+	"io"
 	"log"
+	"math"
 	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
+	"unicode/utf8"
 
-	"image/gif"
 	_ "image/gif"
-	"image/jpeg"
-	"image/png"
+	_ "image/jpeg"
+	_ "image/png"
 
 	pb "github.com/JuLi0n21/thumbnail_service/proto"
 	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"github.com/nfnt/resize"
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func generateVideoThumbnail(inputPath, outputPath string, maxWidth, maxHeight int) error {
-	cmd := exec.Command("ffmpeg", "-y", "-i", inputPath, "-vf", "thumbnail", "-frames:v", "1", outputPath)
+// generateVideoThumbnail extracts the frame selected by frame from the video
+// at inputPath into outputPath without resizing it. Without a selection a
+// representative frame from the start of the video is used.
+func generateVideoThumbnail(inputPath, outputPath string, frame *pb.VideoFrameSelection) error {
+	args, err := frameSelectionArgs(inputPath, frame)
+	if err != nil {
+		return fmt.Errorf("failed to select video frame: %v", err)
+	}
+	args = append([]string{"-y"}, args...)
+	args = append(args, "-frames:v", "1", outputPath)
+
+	cmd := exec.Command("ffmpeg", args...)
 	var stderr strings.Builder
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = cmd.Run()
 
 	if err != nil {
 		return fmt.Errorf("failed to generate video thumbnail using FFmpeg: %v. FFmpeg stderr: %s", err, stderr.String())
 	}
 
-	if maxWidth > 0 || maxHeight > 0 {
-		return resizeImage(outputPath, outputPath, maxWidth, maxHeight)
+	// FFmpeg succeeds without writing a frame if the position is past the end.
+	if _, err := os.Stat(outputPath); err != nil {
+		return errors.New("failed to generate video thumbnail: no frame at the selected position")
 	}
+
 	return nil
 }
 
-func generatePdfThumbnail(inputPath, outputPath string, maxWidth, maxHeight int) error {
+// generatePdfThumbnail renders a single page of the PDF at inputPath into
+// outputPath, which must end in .jpg. scaleTo limits the longer side of the
+// rendered page, 0 renders at the default resolution.
+func generatePdfThumbnail(inputPath, outputPath string, page, scaleTo int) error {
 
 	filename := strings.TrimSuffix(outputPath, ".jpg")
 
 	cmd := exec.Command("pdftoppm",
 		inputPath, filename, "-jpeg",
 		"-singlefile",
-		"-f", "1",
-		"-l", "1",
-		"-scale-to", strconv.Itoa(maxHeight))
-	err := cmd.Run()
+		"-f", strconv.Itoa(page),
+		"-l", strconv.Itoa(page),
+		"-scale-to", strconv.Itoa(scaleTo))
+	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("failed to generate PDF thumbnail using Poppler-utils: %v", err)
+		return fmt.Errorf("failed to generate PDF thumbnail using Poppler-utils: %v\nOutput: %s", err, output)
 	}
 
-	if maxWidth > 0 || maxHeight > 0 {
-		return resizeImage(outputPath, outputPath, maxWidth, maxHeight)
+	return nil
+}
+
+// generatePdfContactSheet renders the pages from firstPage to the last page
+// of the sheet and tiles them into a single grid image at outputPath.
+func generatePdfContactSheet(inputPath, outputPath string, firstPage int, sheet *pb.ContactSheetOptions, background color.Color) error {
+	lastPage := int(sheet.LastPage)
+	if lastPage == 0 {
+		lastPage = firstPage + 8
+	}
+	tileSize := int(sheet.TileSize)
+	if tileSize == 0 {
+		tileSize = 300
 	}
 
-	return nil
+	dir, err := os.MkdirTemp("", "contactsheet-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cmd := exec.Command("pdftoppm",
+		inputPath, filepath.Join(dir, "page"), "-jpeg",
+		"-f", strconv.Itoa(firstPage),
+		"-l", strconv.Itoa(lastPage),
+		"-scale-to", strconv.Itoa(tileSize))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to render PDF pages using Poppler-utils: %v\nOutput: %s", err, output)
+	}
+
+	// pdftoppm pads the page numbers to the same width, so the names sort
+	// in page order.
+	pages, err := filepath.Glob(filepath.Join(dir, "page-*.jpg"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(pages)
+
+	tiles := make([]image.Image, 0, len(pages))
+	for _, page := range pages {
+		tile, err := decodeImageFile(page)
+		if err != nil {
+			return err
+		}
+		tiles = append(tiles, tile)
+	}
+
+	sheetImg := tileImages(tiles, int(sheet.Columns), int(sheet.Spacing), background)
+	return encodeImage(outputPath, sheetImg, imageOptions{format: pb.OutputFormat_JPEG, quality: 90})
 }
 
-func resizeImage(inputPath, outputPath string, maxWidth, maxHeight int) error {
+// resizeImage fits the image at inputPath into maxWidth and maxHeight and
+// writes it to outputPath. It returns the region of the image that was
+// cropped, which is empty if the whole image was used.
+//
+// Animated GIFs stay animated if the output is a GIF as well and they fit
+// maxAnimationPixels, otherwise only their first frame is used.
+func resizeImage(inputPath, outputPath string, maxWidth, maxHeight int, opts imageOptions) (image.Rectangle, error) {
+	if opts.format == pb.OutputFormat_GIF && !opts.firstFrameOnly {
+		if anim, err := decodeAnimatedGif(inputPath); err == nil && len(anim.Image) > 1 {
+			if fitsAnimationLimit(anim) {
+				return resizeAnimatedGif(anim, outputPath, maxWidth, maxHeight, opts)
+			}
+			fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "Animated gif with", len(anim.Image), "frames is too large, using the first frame")
+		}
+	}
 
 	file, err := os.Open(inputPath)
 	if err != nil {
-		return fmt.Errorf("failed to open image file: %v", err)
+		return image.Rectangle{}, fmt.Errorf("failed to open image file: %v", err)
 	}
 	defer file.Close()
 
-	img, imgType, err := image.Decode(file)
+	img, _, err := image.Decode(file)
 	if err != nil {
-		return fmt.Errorf("failed to decode image: %v", err)
+		return image.Rectangle{}, fmt.Errorf("failed to decode image: %v", err)
 	}
 
-	var newWidth, newHeight int
-	if maxWidth > 0 && maxHeight > 0 {
+	img = applyOrientation(img, opts.orientation)
+	resizedImg, crop := fitImage(img, maxWidth, maxHeight, opts)
 
-		newWidth = maxWidth
-		newHeight = maxHeight
-	} else if maxWidth > 0 {
+	return crop, encodeImage(outputPath, resizedImg, opts)
+}
 
-		newWidth = maxWidth
-		newHeight = int(float64(img.Bounds().Dy()) * float64(maxWidth) / float64(img.Bounds().Dx()))
-	} else if maxHeight > 0 {
+type server struct {
+	pb.UnimplementedThumbnailServiceServer
+}
 
-		newHeight = maxHeight
-		newWidth = int(float64(img.Bounds().Dx()) * float64(maxHeight) / float64(img.Bounds().Dy()))
-	} else {
+func (s *server) GenerateThumbnail(ctx context.Context, req *pb.ThumbnailRequest) (*pb.ThumbnailResponse, error) {
+	start := time.Now()
+	fmt.Println(start.Format("2006-01-02 15:04:05.000"), "Thumbnail request ", req.FileType, "H: ", req.MaxHeight, "W: ", req.MaxWidth)
+
+	defer func() {
+		end := time.Since(start)
+		fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "Finshed in: ", end, req.FileType, "H: ", req.MaxHeight, "W: ", req.MaxWidth)
+	}()
 
-		newWidth = img.Bounds().Dx()
-		newHeight = img.Bounds().Dy()
+	inputPath, err := writeTempFile("upload-*", req.FileContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write content to file: %v", err)
 	}
+	defer os.Remove(inputPath)
 
-	resizedImg := resize.Resize(uint(newWidth), uint(newHeight), img, resize.Lanczos3)
+	return generateThumbnail(inputPath, req)
+}
 
-	outFile, err := os.Create(outputPath)
+func (s *server) GenerateThumbnailStream(stream pb.ThumbnailService_GenerateThumbnailStreamServer) error {
+	start := time.Now()
+
+	first, err := stream.Recv()
 	if err != nil {
-		return fmt.Errorf("failed to create output file: %v", err)
+		return fmt.Errorf("failed to receive thumbnail metadata: %v", err)
 	}
-	defer outFile.Close()
-	switch imgType {
-	case "jpeg":
-		err = jpeg.Encode(outFile, resizedImg, nil)
-		if err != nil {
-			return fmt.Errorf("failed to save resized image as jpeg: %v", err)
+	req := first.GetMetadata()
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "first message of the stream must carry the thumbnail metadata")
+	}
+
+	fmt.Println(start.Format("2006-01-02 15:04:05.000"), "Thumbnail stream request ", req.FileType, "H: ", req.MaxHeight, "W: ", req.MaxWidth)
+
+	tempFile, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	if _, err := tempFile.Write(req.FileContent); err != nil {
+		return fmt.Errorf("failed to write content to file: %v", err)
+	}
+
+	size := int64(len(req.FileContent))
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
 		}
-	case "png":
-		err = png.Encode(outFile, resizedImg)
 		if err != nil {
-			return fmt.Errorf("failed to save resized image as png: %v", err)
+			return fmt.Errorf("failed to receive chunk: %v", err)
+		}
+		if msg.GetMetadata() != nil {
+			return status.Error(codes.InvalidArgument, "only the first message of the stream may carry the thumbnail metadata")
 		}
-	case "gif":
-		err = gif.Encode(outFile, resizedImg, &gif.Options{})
+
+		n, err := tempFile.Write(msg.GetChunk())
 		if err != nil {
-			return fmt.Errorf("failed to save resized image as png: %v", err)
+			return fmt.Errorf("failed to write chunk to file: %v", err)
 		}
-	default:
-		return fmt.Errorf("unsupported image type: %v", imgType)
+		size += int64(n)
 	}
 
-	return nil
-}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to write content to file: %v", err)
+	}
 
-type server struct {
-	pb.UnimplementedThumbnailServiceServer
+	resp, err := generateThumbnail(tempFile.Name(), req)
+	if err != nil {
+		return err
+	}
+
+	end := time.Since(start)
+	fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "Stream finshed in: ", end, req.FileType, "Size: ", size, "H: ", req.MaxHeight, "W: ", req.MaxWidth)
+
+	return stream.SendAndClose(resp)
 }
 
-func (s *server) GenerateThumbnail(ctx context.Context, req *pb.ThumbnailRequest) (*pb.ThumbnailResponse, error) {
+func (s *server) GenerateThumbnails(ctx context.Context, req *pb.ThumbnailBatchRequest) (*pb.ThumbnailBatchResponse, error) {
 	start := time.Now()
-	fmt.Println(start.Format("2006-01-02 15:04:05.000"), "Thumbnail request ", req.FileType, "H: ", req.MaxHeight, "W: ", req.MaxWidth)
+	fmt.Println(start.Format("2006-01-02 15:04:05.000"), "Thumbnail batch request ", len(req.Items), "items")
+
+	results := make([]*pb.ThumbnailBatchResult, len(req.Items))
+	sem := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+
+	for i, item := range req.Items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i] = batchResult(item.Id, nil, ctx.Err())
+				return
+			}
 
-	tempFile, err := os.CreateTemp("", "upload-*")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temporary file: %v", err)
+			resp, err := generateBatchThumbnail(item)
+			results[i] = batchResult(item.Id, resp, err)
+		}()
 	}
-	defer os.Remove(tempFile.Name())
+	wg.Wait()
 
-	err = os.WriteFile(tempFile.Name(), req.FileContent, 0644)
+	succeeded := 0
+	for _, result := range results {
+		if result.Success {
+			succeeded++
+		}
+	}
+
+	end := time.Since(start)
+	fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "Batch finshed in: ", end, succeeded, "of", len(results), "succeeded")
+
+	return &pb.ThumbnailBatchResponse{
+		Message: fmt.Sprintf("Generated %d of %d thumbnails", succeeded, len(results)),
+		Results: results,
+	}, nil
+}
+
+func generateBatchThumbnail(item *pb.ThumbnailBatchItem) (*pb.ThumbnailResponse, error) {
+	if item.Request == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing thumbnail request")
+	}
+
+	inputPath, err := writeTempFile("upload-*", item.Request.FileContent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to write content to file: %v", err)
 	}
+	defer os.Remove(inputPath)
+
+	return generateThumbnail(inputPath, item.Request)
+}
 
-	thumbnailName := fmt.Sprintf("thumbnail-%s.jpg", uuid.New().String())
-	outputPath := filepath.Join("thumbnails", thumbnailName)
+func batchResult(id string, resp *pb.ThumbnailResponse, err error) *pb.ThumbnailBatchResult {
+	if err != nil {
+		fmt.Println("batch item", id, "failed:", err)
+		return &pb.ThumbnailBatchResult{
+			Id:    id,
+			Error: err.Error(),
+		}
+	}
+	return &pb.ThumbnailBatchResult{
+		Id:       id,
+		Success:  true,
+		Response: resp,
+	}
+}
 
+// generateThumbnail runs the generator matching req.FileType on the file at
+// inputPath. The file content of req is ignored, the caller is responsible for
+// writing it to inputPath.
+//
+// Videos and PDFs are decoded only once, every requested size is derived from
+// the same extracted frame.
+func generateThumbnail(inputPath string, req *pb.ThumbnailRequest) (*pb.ThumbnailResponse, error) {
 	if err := os.MkdirAll("thumbnails", 0755); err != nil {
 		return nil, fmt.Errorf("failed to create thumbnails directory: %v", err)
 	}
 
-	switch req.FileType {
+	opts, err := newImageOptions(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := req.Sizes
+	if len(sizes) == 0 {
+		sizes = []*pb.ThumbnailSize{{MaxWidth: req.MaxWidth, MaxHeight: req.MaxHeight}}
+	}
+
+	id := uuid.New().String()
+	framePath := filepath.Join("thumbnails", fmt.Sprintf("thumbnail-%s.jpg", id))
+	defer removeIfExists(framePath)
+
+	fileType := req.FileType
+	if fileType == pb.FileType_FILE_TYPE_UNSPECIFIED {
+		fileType, err = detectFileType(inputPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Storyboard != nil {
+		return generateStoryboardResponse(inputPath, fileType, req, opts)
+	}
+	if req.AnimatedPreview != nil {
+		return generateAnimatedPreviewResponse(inputPath, framePath, fileType, req, opts)
+	}
+
+	sourcePath := framePath
+	repair := &pdfRepair{path: inputPath}
+	switch fileType {
 	case pb.FileType_IMAGE:
-		err = resizeImage(tempFile.Name(), outputPath, int(req.MaxWidth), int(req.MaxHeight))
+		sourcePath = inputPath
+		if !req.DisableAutoOrient {
+			opts.orientation = jpegOrientation(inputPath)
+		}
 	case pb.FileType_VIDEO:
-		err = generateVideoThumbnail(tempFile.Name(), outputPath, int(req.MaxWidth), int(req.MaxHeight))
+		if err := validateFrameSelection(req.VideoFrame); err != nil {
+			return nil, err
+		}
+		err = generateVideoThumbnail(inputPath, framePath, req.VideoFrame)
 	case pb.FileType_PDF:
-		err = generatePdfThumbnail(tempFile.Name(), outputPath, int(req.MaxWidth), int(req.MaxHeight))
+		if err := validatePageSelection(req); err != nil {
+			return nil, err
+		}
+		if err := checkPDFPassword(inputPath, req.Password); err != nil {
+			return nil, err
+		}
+		// inputPath is a temporary copy of the upload, so it is decrypted
+		// in place for pdftoppm.
+		if isEncrypted(inputPath) {
+			if err := decryptPDF(inputPath, req.Password); err != nil {
+				return nil, err
+			}
+		}
+		page := max(1, int(req.Page))
+		err = repair.retry(func() error {
+			info, err := pdfInfo(inputPath)
+			if err != nil {
+				return err
+			}
+			if page > int(info.PageCount) {
+				return status.Errorf(codes.InvalidArgument, "page %d is past the end of the document with %d pages", page, info.PageCount)
+			}
+			if req.ContactSheet != nil {
+				return generatePdfContactSheet(inputPath, framePath, page, req.ContactSheet, contactSheetBackground(req))
+			}
+			pageWidth, pageHeight := pdfPageSize(info, page)
+			return generatePdfThumbnail(inputPath, framePath, page, pdfScaleTo(sizes, pageWidth, pageHeight))
+		})
 	default:
-		return nil, fmt.Errorf("unsupported file type: %v", req.FileType)
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported file type: %v", fileType)
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	defer func() {
-		if _, err := os.Stat(outputPath); err == nil {
-			os.Remove(outputPath)
+	opts.format = resolveOutputFormat(opts.format, sourcePath)
+	// Extracted frames are JPEGs already and can be returned as they are if
+	// they need no resizing and no other encoding was requested.
+	reencode := sourcePath == inputPath || opts.format != pb.OutputFormat_JPEG || opts.quality > 0
+
+	renditions := make([]*pb.ThumbnailRendition, 0, len(sizes))
+	for i, size := range sizes {
+		outputPath := filepath.Join("thumbnails", fmt.Sprintf("thumbnail-%s-%d%s", id, i, fileExtension(opts.format)))
+		rendition, err := renderThumbnail(sourcePath, outputPath, int(size.MaxWidth), int(size.MaxHeight), opts, reencode)
+		if err != nil {
+			return nil, err
 		}
-		end := time.Since(start)
-		fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "Finshed in: ", end, req.FileType, "H: ", req.MaxHeight, "W: ", req.MaxWidth)
-	}()
+		renditions = append(renditions, rendition)
+	}
 
-	thumbnailContent, err := os.ReadFile(outputPath)
+	if len(req.Sizes) > 0 {
+		return &pb.ThumbnailResponse{
+			Message:            fmt.Sprintf("%d thumbnails generated successfully", len(renditions)),
+			Renditions:         renditions,
+			MimeType:           mimeType(opts.format),
+			DetectedFileType:   fileType,
+			AppliedOrientation: int32(opts.orientation),
+			Repaired:           repair.repaired,
+		}, nil
+	}
+
+	return &pb.ThumbnailResponse{
+		Message:            "Thumbnail generated successfully",
+		ThumbnailContent:   renditions[0].Content,
+		MimeType:           mimeType(opts.format),
+		DetectedFileType:   fileType,
+		Crop:               renditions[0].Crop,
+		AppliedOrientation: int32(opts.orientation),
+		Repaired:           repair.repaired,
+	}, nil
+}
+
+func generateStoryboardResponse(inputPath string, fileType pb.FileType, req *pb.ThumbnailRequest, opts imageOptions) (*pb.ThumbnailResponse, error) {
+	if fileType != pb.FileType_VIDEO {
+		return nil, fmt.Errorf("storyboards are only supported for videos, got %v", fileType)
+	}
+	if opts.format == pb.OutputFormat_OUTPUT_FORMAT_UNSPECIFIED {
+		opts.format = pb.OutputFormat_JPEG
+	}
+
+	storyboard, err := generateStoryboard(inputPath, req.Storyboard, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read generated thumbnail: %v", err)
+		return nil, err
+	}
+
+	return &pb.ThumbnailResponse{
+		Message:          fmt.Sprintf("Storyboard with %d sprites generated successfully", len(storyboard.Sprites)),
+		MimeType:         mimeType(opts.format),
+		DetectedFileType: fileType,
+		Storyboard:       storyboard,
+	}, nil
+}
+
+func generateAnimatedPreviewResponse(inputPath, framePath string, fileType pb.FileType, req *pb.ThumbnailRequest, opts imageOptions) (*pb.ThumbnailResponse, error) {
+	if fileType != pb.FileType_VIDEO {
+		return nil, fmt.Errorf("animated previews are only supported for videos, got %v", fileType)
+	}
+	if opts.format != pb.OutputFormat_OUTPUT_FORMAT_UNSPECIFIED && opts.format != pb.OutputFormat_GIF {
+		return nil, fmt.Errorf("animated previews are only available as GIF, got %v", opts.format)
+	}
+
+	outputPath := strings.TrimSuffix(framePath, ".jpg") + ".gif"
+	defer removeIfExists(outputPath)
+
+	crop, err := generateAnimatedPreview(inputPath, outputPath, req.AnimatedPreview, int(req.MaxWidth), int(req.MaxHeight), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated preview: %v", err)
 	}
 
 	return &pb.ThumbnailResponse{
-		Message:          "Thumbnail generated successfully",
-		ThumbnailContent: thumbnailContent,
+		Message:          "Animated preview generated successfully",
+		ThumbnailContent: content,
+		MimeType:         mimeType(pb.OutputFormat_GIF),
+		DetectedFileType: fileType,
+		Crop:             cropRectMessage(crop),
 	}, nil
 }
 
+// renderThumbnail resizes the image at sourcePath into outputPath and returns
+// the result. Extracted frames that need no resizing are returned unchanged,
+// uploaded images are always re-encoded.
+func renderThumbnail(sourcePath, outputPath string, maxWidth, maxHeight int, opts imageOptions, reencode bool) (*pb.ThumbnailRendition, error) {
+	defer removeIfExists(outputPath)
+
+	var crop image.Rectangle
+	if maxWidth > 0 || maxHeight > 0 || reencode {
+		var err error
+		crop, err = resizeImage(sourcePath, outputPath, maxWidth, maxHeight, opts)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		outputPath = sourcePath
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated thumbnail: %v", err)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode generated thumbnail: %v", err)
+	}
+
+	return &pb.ThumbnailRendition{
+		Width:   int32(config.Width),
+		Height:  int32(config.Height),
+		Content: content,
+		Crop:    cropRectMessage(crop),
+	}, nil
+}
+
+// pdfScaleTo returns the -scale-to value for pdftoppm, the longer side of the
+// rendered page in pixels, so that a page of pageWidth by pageHeight points
+// covers every requested size in both dimensions. Sizes without any limit or
+// an unknown page size fall back to the default resolution.
+func pdfScaleTo(sizes []*pb.ThumbnailSize, pageWidth, pageHeight float64) int {
+	if pageWidth <= 0 || pageHeight <= 0 {
+		return 0
+	}
+
+	scale := 0.0
+	for _, size := range sizes {
+		if size.MaxWidth <= 0 && size.MaxHeight <= 0 {
+			return 0
+		}
+		scale = max(scale, float64(size.MaxWidth)/pageWidth, float64(size.MaxHeight)/pageHeight)
+	}
+	return int(math.Ceil(max(pageWidth, pageHeight) * scale))
+}
+
+// pdfPageSize returns the size of page in points, or 0, 0 if info doesn't
+// list it.
+func pdfPageSize(info *pb.PdfInfo, page int) (float64, float64) {
+	for _, size := range info.PageSizes {
+		if int(size.Page) == page {
+			return size.Width, size.Height
+		}
+	}
+	return 0, 0
+}
+
+func validatePageSelection(req *pb.ThumbnailRequest) error {
+	if req.Page < 0 {
+		return status.Errorf(codes.InvalidArgument, "page must not be negative, got %d", req.Page)
+	}
+
+	sheet := req.ContactSheet
+	if sheet == nil {
+		return nil
+	}
+	firstPage := max(1, req.Page)
+	if sheet.LastPage != 0 && sheet.LastPage < firstPage {
+		return status.Errorf(codes.InvalidArgument, "last page %d of the contact sheet is before the first page %d", sheet.LastPage, firstPage)
+	}
+	if sheet.LastPage != 0 && sheet.LastPage-firstPage >= maxContactSheetPages {
+		return status.Errorf(codes.InvalidArgument, "contact sheet spans %d pages, at most %d are allowed", sheet.LastPage-firstPage+1, maxContactSheetPages)
+	}
+	if sheet.Columns < 0 || sheet.TileSize < 0 || sheet.Spacing < 0 {
+		return status.Error(codes.InvalidArgument, "contact sheet columns, tile size and spacing must not be negative")
+	}
+	return nil
+}
+
+func contactSheetBackground(req *pb.ThumbnailRequest) color.Color {
+	if c, err := parseHexColor(req.BackgroundColor); err == nil {
+		return c
+	}
+	return color.White
+}
+
+func removeIfExists(path string) {
+	if _, err := os.Stat(path); err == nil {
+		os.Remove(path)
+	}
+}
+
 func (s *server) OcrFile(ctx context.Context, req *pb.OCRFileRequest) (*pb.OCRFileResponse, error) {
 	start := time.Now()
 	fmt.Println(start.Format("2006-01-02 15:04:05.000"), "OCR request ", req.FileType)
@@ -199,59 +613,366 @@ func (s *server) OcrFile(ctx context.Context, req *pb.OCRFileRequest) (*pb.OCRFi
 		fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "OCR Finshed in: ", end, req.FileType)
 	}()
 
-	if req.FileType != pb.FileType_PDF {
-		err := errors.New("unsupported Filetype " + req.FileType.String())
-		return handleErr(err.Error(), err)
+	filePath, err := writeTempFile("temp-file-*", req.FileContent)
+	if err != nil {
+		return handleErr("failed to write file to temp file", err)
+	}
+	defer removeTempFile(filePath)
+
+	resp, err := ocrFile(filePath, req)
+	if err != nil {
+		return resp, err
 	}
-	file, err := os.CreateTemp("", "temp-file-*")
+
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return handleErr("failed to read processed file", err)
+	}
+	resp.OcrContent = b
+
+	return resp, nil
+}
+
+func (s *server) OcrFileStream(req *pb.OCRFileRequest, stream pb.ThumbnailService_OcrFileStreamServer) error {
+	start := time.Now()
+	fmt.Println(start.Format("2006-01-02 15:04:05.000"), "OCR stream request ", req.FileType)
+
+	defer func() {
+		end := time.Since(start)
+		fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "OCR stream Finshed in: ", end, req.FileType)
+	}()
+
+	filePath, err := writeTempFile("temp-file-*", req.FileContent)
+	if err != nil {
+		_, err = handleErr("failed to write file to temp file", err)
+		return err
+	}
+	defer removeTempFile(filePath)
+
+	resp, err := ocrFile(filePath, req)
 	if err != nil {
-		return handleErr("failed to create temp file", err)
+		return err
 	}
-	filePath := file.Name()
-	defer func(file *os.File, filePath string) {
-		file.Close()
 
-		err = os.Remove(file.Name())
+	text := resp.TextContent
+	resp.TextContent = ""
+	for len(text) > 0 {
+		n := textChunkLen(text, streamChunkSize)
+		err := stream.Send(&pb.OCRFileChunk{Data: &pb.OCRFileChunk_TextContent{TextContent: text[:n]}})
 		if err != nil {
-			fmt.Println(err.Error())
+			return fmt.Errorf("failed to send text chunk: %v", err)
 		}
-	}(file, filePath)
+		text = text[n:]
+	}
+
+	pages := resp.Pages
+	resp.Pages = nil
+	for _, page := range pages {
+		if err := stream.Send(&pb.OCRFileChunk{Data: &pb.OCRFileChunk_Page{Page: page}}); err != nil {
+			return fmt.Errorf("failed to send page: %v", err)
+		}
+	}
+
+	layoutContent := resp.LayoutContent
+	resp.LayoutContent = ""
+	for len(layoutContent) > 0 {
+		n := textChunkLen(layoutContent, streamChunkSize)
+		err := stream.Send(&pb.OCRFileChunk{Data: &pb.OCRFileChunk_LayoutContent{LayoutContent: layoutContent[:n]}})
+		if err != nil {
+			return fmt.Errorf("failed to send layout chunk: %v", err)
+		}
+		layoutContent = layoutContent[n:]
+	}
+
+	layout := resp.Layout
+	resp.Layout = nil
+	for _, page := range layout {
+		if err := stream.Send(&pb.OCRFileChunk{Data: &pb.OCRFileChunk_Layout{Layout: page}}); err != nil {
+			return fmt.Errorf("failed to send layout page: %v", err)
+		}
+	}
 
-	_, err = file.Write(req.FileContent)
+	file, err := os.Open(filePath)
 	if err != nil {
-		return handleErr("failed to write file to temp file", err)
+		_, err = handleErr("failed to read processed file", err)
+		return err
+	}
+	defer file.Close()
+
+	buf := make([]byte, streamChunkSize)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			// Send copies the chunk into the wire format, so buf can be reused.
+			if err := stream.Send(&pb.OCRFileChunk{Data: &pb.OCRFileChunk_OcrContent{OcrContent: buf[:n]}}); err != nil {
+				return fmt.Errorf("failed to send file chunk: %v", err)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			_, err = handleErr("failed to read processed file", err)
+			return err
+		}
 	}
 
-	if ok, err := isScannedPDF(filePath); err != nil {
-		return handleErr("failed to check if file is scanned", err)
-	} else if !ok {
-		if isEncrypted(filePath) {
-			err := decryptPDF(filePath)
+	return stream.Send(&pb.OCRFileChunk{Data: &pb.OCRFileChunk_Summary{Summary: resp}})
+}
+
+// ocrFile runs the OCR pipeline on the PDF or image at filePath. Images are
+// converted to a PDF first. The file is replaced by the processed PDF, the
+// returned response only carries the message and the extracted text so
+// callers can decide how to deliver the file itself.
+func ocrFile(filePath string, req *pb.OCRFileRequest) (*pb.OCRFileResponse, error) {
+	fileType := req.FileType
+	if fileType == pb.FileType_FILE_TYPE_UNSPECIFIED {
+		detected, err := detectFileType(filePath)
+		if err != nil {
+			return handleErr("failed to detect file type", err)
+		}
+		fileType = detected
+	}
+
+	switch fileType {
+	case pb.FileType_PDF:
+	case pb.FileType_IMAGE:
+		if err := imageToPDF(filePath); err != nil {
+			return handleErr("failed to convert image to pdf", err)
+		}
+	default:
+		err := status.Error(codes.InvalidArgument, "unsupported Filetype "+fileType.String())
+		return handleErr(err.Error(), err)
+	}
+
+	if err := validateLanguages(req.Languages); err != nil {
+		return handleErr("invalid languages", err)
+	}
+
+	if err := validateOcrOptions(req.Options); err != nil {
+		return handleErr("invalid ocr options", err)
+	}
+
+	if err := checkPDFPassword(filePath, req.Password); err != nil {
+		return handleErr("failed to open pdf", err)
+	}
+
+	// Encrypted PDFs are processed as a decrypted copy, which replaces the
+	// uploaded file only if it had to be modified.
+	pdfPath := filePath
+	if isEncrypted(filePath) {
+		decrypted, err := decryptedCopy(filePath, req.Password)
+		if err != nil {
+			return handleErr("failed to decrypt pdf", err)
+		}
+		defer os.Remove(decrypted)
+		pdfPath = decrypted
+	}
+
+	var languages []string
+	var hocrDir string
+	repair := &pdfRepair{path: pdfPath}
+
+	var text string
+	var pageTexts []string
+	extractText := func() (err error) {
+		text, err = extractTextFromPDF(pdfPath)
+		pageTexts = splitPages(text)
+		return err
+	}
+	if err := repair.retry(extractText); err != nil {
+		return handleErr("failed to extract text", err)
+	}
+
+	// Unless the mode says otherwise, only pages with (almost) no text are
+	// OCRed, born-digital pages of mixed documents keep their original text.
+	ocrPages := ocrTargetPages(req.Options, pageTexts)
+	// PDF/A output is converted by ocrmypdf, even if there is nothing to OCR.
+	pdfaPart := requestedPDFAPart(req.Options)
+	modified := len(ocrPages) > 0 || pdfaPart > 0
+	if modified {
+		if len(ocrPages) > 0 {
+			languages = ocrLanguages(pdfPath, ocrPages, req.Languages, req.DetectLanguage)
+		}
+
+		// The layout takes the confidence of OCRed words from the hOCR.
+		if req.LayoutFormat != pb.LayoutFormat_LAYOUT_FORMAT_NONE && len(ocrPages) > 0 {
+			var err error
+			hocrDir, err = os.MkdirTemp("", "ocrmypdf-*")
 			if err != nil {
-				return handleErr("failed to decrypt pdf", err)
+				return handleErr("failed to create temporary directory", err)
 			}
+			defer os.RemoveAll(hocrDir)
 		}
 
-		err = runOCRMyPDF(filePath)
+		err := repair.retry(func() error { return runOCRMyPDF(pdfPath, languages, ocrPages, req.Options, hocrDir) })
 		if err != nil {
 			return handleErr("failed to ocr pdf", err)
 		}
+
+		if err := repair.retry(extractText); err != nil {
+			return handleErr("failed to extract text", err)
+		}
 	}
 
-	text, b, err := extractTextFromPDF(filePath)
+	level, part, err := claimedPDFALevel(pdfPath)
 	if err != nil {
-		return handleErr("failed to extract text", err)
+		return handleErr("failed to read pdf/a level", err)
+	}
+	if pdfaPart > 0 && part != pdfaPart {
+		err := fmt.Errorf("requested PDF/A-%d, but the output claims %q", pdfaPart, level)
+		return handleErr("failed to convert to pdf/a", err)
 	}
 
-	if req.CleanUp {
-		text = cleanOCRText(text)
+	if cleanup := cleanupOptions(req); cleanup != nil {
+		text = cleanText(text, cleanup)
+		pageTexts = cleanPages(pageTexts, cleanup)
 	}
 
-	return &pb.OCRFileResponse{
-		Message:     "OCR success",
-		TextContent: text,
-		OcrContent:  b,
-	}, nil
+	pages := make([]*pb.OCRPage, 0, len(pageTexts))
+	for i, pageText := range pageTexts {
+		pages = append(pages, &pb.OCRPage{
+			PageNumber: int32(i + 1),
+			Text:       pageText,
+			Ocred:      slices.Contains(ocrPages, int32(i+1)),
+			CharCount:  int32(utf8.RuneCountInString(pageText)),
+		})
+	}
+
+	resp := &pb.OCRFileResponse{
+		Message:          "OCR success",
+		TextContent:      text,
+		DetectedFileType: fileType,
+		Languages:        languages,
+		Pages:            pages,
+		Repaired:         repair.repaired,
+		OcrPages:         ocrPages,
+		ClaimedPdfaLevel: level,
+	}
+
+	if req.LayoutFormat != pb.LayoutFormat_LAYOUT_FORMAT_NONE {
+		layout, err := extractLayoutFromPDF(pdfPath)
+		if err != nil {
+			return handleErr("failed to extract layout", err)
+		}
+		if hocrDir != "" {
+			hocr, err := readOCRMyPDFHOCR(hocrDir)
+			if err != nil {
+				return handleErr("failed to read ocr confidence", err)
+			}
+			applyHOCRConfidence(layout, hocr)
+		}
+
+		resp.LayoutContent, err = formatLayout(layout, req.LayoutFormat)
+		if err != nil {
+			return handleErr("failed to format layout", err)
+		}
+		resp.Layout = layout
+	}
+
+	if pdfPath != filePath && (modified || repair.repaired) {
+		if err := replaceFile(pdfPath, filePath); err != nil {
+			return handleErr("failed to write processed file", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// writeTempFile writes content to a new temporary file and returns its path.
+func writeTempFile(pattern string, content []byte) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = file.Write(content)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+
+	return file.Name(), nil
+}
+
+func removeTempFile(path string) {
+	if err := os.Remove(path); err != nil {
+		fmt.Println(err.Error())
+	}
+}
+
+// textChunkLen returns the length of the next chunk of text that is at most
+// max bytes long without splitting a multi-byte rune.
+func textChunkLen(text string, max int) int {
+	if len(text) <= max {
+		return len(text)
+	}
+	n := max
+	for n > 0 && !utf8.RuneStart(text[n]) {
+		n--
+	}
+	if n == 0 {
+		return max
+	}
+	return n
+}
+
+func (s *server) GetFileInfo(ctx context.Context, req *pb.FileInfoRequest) (*pb.FileInfoResponse, error) {
+	start := time.Now()
+	fmt.Println(start.Format("2006-01-02 15:04:05.000"), "File info request ", req.FileType)
+
+	defer func() {
+		end := time.Since(start)
+		fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "File info Finshed in: ", end, req.FileType)
+	}()
+
+	filePath, err := writeTempFile("info-*", req.FileContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write content to file: %v", err)
+	}
+	defer removeTempFile(filePath)
+
+	fileType := req.FileType
+	if fileType == pb.FileType_FILE_TYPE_UNSPECIFIED {
+		fileType, err = detectFileType(filePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &pb.FileInfoResponse{
+		Message:          "File info read successfully",
+		DetectedFileType: fileType,
+		Size:             int64(len(req.FileContent)),
+	}
+
+	switch fileType {
+	case pb.FileType_IMAGE:
+		info, err := imageInfo(filePath)
+		if err != nil {
+			return nil, err
+		}
+		resp.Info = &pb.FileInfoResponse_Image{Image: info}
+	case pb.FileType_PDF:
+		info, err := pdfInfo(filePath)
+		if err != nil {
+			return nil, err
+		}
+		resp.Info = &pb.FileInfoResponse_Pdf{Pdf: info}
+	case pb.FileType_VIDEO:
+		info, err := probeVideo(filePath)
+		if err != nil {
+			return nil, err
+		}
+		resp.Info = &pb.FileInfoResponse_Video{Video: info}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported file type: %v", fileType)
+	}
+
+	return resp, nil
 }
 
 func handleErr(message string, err error) (*pb.OCRFileResponse, error) {
@@ -266,7 +987,13 @@ func handleErr(message string, err error) (*pb.OCRFileResponse, error) {
 const (
 	grpcPort   = ":50051"
 	restPort   = ":8080"
-	maxMsgSize = 1024 * 1024 * 2048 // 50 MB (adjust if needed)
+	maxMsgSize = 1024 * 1024 * 2048 // 2 GB, large files should rather be sent through the streaming RPCs
+
+	streamChunkSize = 1024 * 256 // 256 KB per streamed message
+
+	batchConcurrency = 4 // items of a GenerateThumbnails call processed at the same time
+
+	maxContactSheetPages = 100 // pages a single contact sheet may span
 )
 
 func main() {
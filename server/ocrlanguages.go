@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultOCRLanguage is the language tesseract uses if none is given.
+	defaultOCRLanguage = "eng"
+
+	// maxDetectPages is the number of pages script detection is tried on.
+	maxDetectPages = 3
+)
+
+// languageScripts maps tesseract language codes to the script names reported
+// by tesseract's orientation and script detection.
+var languageScripts = map[string]string{
+	"eng":     "Latin",
+	"deu":     "Latin",
+	"fra":     "Latin",
+	"spa":     "Latin",
+	"ita":     "Latin",
+	"por":     "Latin",
+	"nld":     "Latin",
+	"pol":     "Latin",
+	"ces":     "Latin",
+	"tur":     "Latin",
+	"rus":     "Cyrillic",
+	"ukr":     "Cyrillic",
+	"bul":     "Cyrillic",
+	"ell":     "Greek",
+	"ara":     "Arabic",
+	"heb":     "Hebrew",
+	"hin":     "Devanagari",
+	"tha":     "Thai",
+	"chi_sim": "Han",
+	"chi_tra": "Han",
+	"jpn":     "Japanese",
+	"kor":     "Hangul",
+}
+
+var (
+	installedLanguagesMu    sync.Mutex
+	installedLanguagesCache []string
+)
+
+// installedLanguages lists the tesseract languages available for OCR. The
+// installed data does not change at runtime, so the list is read once it is
+// known; failures are not cached and the next call asks tesseract again.
+func installedLanguages() ([]string, error) {
+	installedLanguagesMu.Lock()
+	defer installedLanguagesMu.Unlock()
+	if installedLanguagesCache != nil {
+		return installedLanguagesCache, nil
+	}
+
+	cmd := exec.Command("tesseract", "--list-langs")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("tesseract failed: %v\nOutput: %s", err, output)
+	}
+
+	languages := []string{}
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// The first line is a header, osd only holds the detection model.
+		if line == "" || strings.HasPrefix(line, "List of") || line == "osd" {
+			continue
+		}
+		languages = append(languages, line)
+	}
+	installedLanguagesCache = languages
+	return languages, nil
+}
+
+// validateLanguages checks that all requested languages are installed.
+func validateLanguages(languages []string) error {
+	if len(languages) == 0 {
+		return nil
+	}
+
+	installed, err := installedLanguages()
+	if err != nil {
+		return err
+	}
+	for _, lang := range languages {
+		if !slices.Contains(installed, lang) {
+			return status.Errorf(codes.InvalidArgument, "language %q is not installed, available languages: %s", lang, strings.Join(installed, ", "))
+		}
+	}
+	return nil
+}
+
+// detectLanguages runs tesseract's orientation and script detection on the
+// given page of the PDF at path and returns all installed languages written
+// in the detected script.
+func detectLanguages(path string, page int32) ([]string, error) {
+	dir, err := os.MkdirTemp("", "osd-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := strconv.Itoa(int(page))
+	imagePath := filepath.Join(dir, "page")
+	cmd := exec.Command("pdftoppm", path, imagePath, "-png", "-singlefile", "-f", p, "-l", p, "-r", "300")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("pdftoppm failed: %v\nOutput: %s", err, output)
+	}
+
+	cmd = exec.Command("tesseract", imagePath+".png", "-", "--psm", "0")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("tesseract script detection failed: %v\nOutput: %s", err, output)
+	}
+
+	script := ""
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "Script: "); ok {
+			script = strings.TrimSpace(value)
+		}
+	}
+	if script == "" {
+		return nil, fmt.Errorf("tesseract detected no script\nOutput: %s", output)
+	}
+
+	installed, err := installedLanguages()
+	if err != nil {
+		return nil, err
+	}
+
+	var languages []string
+	for _, lang := range installed {
+		if languageScripts[lang] == script {
+			languages = append(languages, lang)
+		}
+	}
+	if len(languages) == 0 {
+		return nil, fmt.Errorf("no installed language is written in the detected script %s", script)
+	}
+	return languages, nil
+}
+
+// ocrLanguages returns the languages the PDF at path is OCRed with: the
+// requested ones plus, if asked for, the ones detected on the first of pages
+// tesseract finds a script on. Blank cover or separator pages have none, so
+// up to maxDetectPages pages are tried; if none has a script the detection is
+// skipped. Without any language, tesseract's default eng is used.
+func ocrLanguages(path string, pages []int32, requested []string, detect bool) []string {
+	languages := slices.Clone(requested)
+	if detect {
+		var detected []string
+		for _, page := range pages[:min(len(pages), maxDetectPages)] {
+			var err error
+			detected, err = detectLanguages(path, page)
+			if err == nil {
+				break
+			}
+			fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "Language detection on page", page, "of", path, "failed:", err)
+		}
+		for _, lang := range detected {
+			if !slices.Contains(languages, lang) {
+				languages = append(languages, lang)
+			}
+		}
+	}
+
+	if len(languages) == 0 {
+		languages = []string{defaultOCRLanguage}
+	}
+	return languages
+}
@@ -2,102 +2,445 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
+
+	pb "github.com/JuLi0n21/thumbnail_service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// minPageTextChars is the number of non-space characters a page needs in its
+// text layer to count as born-digital. Scanners often add a few characters,
+// like a page number, a stamp or a Bates ID, to otherwise image-only pages.
+const minPageTextChars = 20
+
+// validateOcrOptions rejects option combinations ocrmypdf refuses to run with.
+func validateOcrOptions(opts *pb.OcrOptions) error {
+	if opts == nil {
+		return nil
+	}
+
+	if _, ok := pb.OcrMode_name[int32(opts.Mode)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown ocr mode %d", opts.Mode)
+	}
+	if _, ok := pb.OptimizeLevel_name[int32(opts.Optimize)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown optimize level %d", opts.Optimize)
+	}
+	if _, ok := pb.OcrOutputType_name[int32(opts.OutputType)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown output type %d", opts.OutputType)
+	}
+	if opts.Mode == pb.OcrMode_REDO_OCR && (opts.Deskew || opts.CleanFinal) {
+		return status.Error(codes.InvalidArgument, "deskew and clean_final can't be combined with REDO_OCR")
+	}
+	return nil
+}
+
+// ocrTargetPages returns the pages the mode of opts OCRs, given the text
+// every page already holds. By default these are the pages with fewer than
+// minPageTextChars non-space characters, SKIP_TEXT only takes pages without
+// any text, like ocrmypdf --skip-text does.
+func ocrTargetPages(opts *pb.OcrOptions, pageTexts []string) []int32 {
+	var pages []int32
+	for i, text := range pageTexts {
+		chars := 0
+		for _, r := range text {
+			if !unicode.IsSpace(r) {
+				chars++
+			}
+		}
+
+		var ocr bool
+		switch opts.GetMode() {
+		case pb.OcrMode_FORCE_OCR, pb.OcrMode_REDO_OCR:
+			ocr = true
+		case pb.OcrMode_SKIP_TEXT:
+			ocr = chars == 0
+		default:
+			ocr = chars < minPageTextChars
+		}
+		if ocr {
+			pages = append(pages, int32(i+1))
+		}
+	}
+	return pages
+}
+
+// ocrMyPDFArgs translates opts into ocrmypdf arguments for OCRing pages,
+// as returned by ocrTargetPages.
+func ocrMyPDFArgs(opts *pb.OcrOptions, pages []int32) []string {
+	var args []string
+	switch opts.GetMode() {
+	case pb.OcrMode_FORCE_OCR:
+		args = append(args, "--force-ocr")
+	case pb.OcrMode_REDO_OCR:
+		args = append(args, "--redo-ocr")
+	case pb.OcrMode_SKIP_TEXT:
+		args = append(args, "--skip-text")
+	default:
+		// The selected pages have too little text to keep, so they are
+		// rasterized and OCRed while all others pass through untouched.
+		if len(pages) > 0 {
+			pageList := make([]string, 0, len(pages))
+			for _, page := range pages {
+				pageList = append(pageList, strconv.Itoa(int(page)))
+			}
+			args = append(args, "--force-ocr", "--pages", strings.Join(pageList, ","))
+		} else {
+			// Nothing to OCR, ocrmypdf only converts the output type.
+			args = append(args, "--skip-text")
+		}
+	}
+
+	if opts.GetDeskew() {
+		args = append(args, "--deskew")
+	}
+	if opts.GetRotatePages() {
+		args = append(args, "--rotate-pages")
+	}
+	if opts.GetClean() {
+		args = append(args, "--clean")
+	}
+	if opts.GetCleanFinal() {
+		args = append(args, "--clean-final")
+	}
+
+	switch opts.GetOptimize() {
+	case pb.OptimizeLevel_OPTIMIZE_NONE:
+		args = append(args, "--optimize", "0")
+	case pb.OptimizeLevel_OPTIMIZE_LOSSLESS:
+		args = append(args, "--optimize", "1")
+	case pb.OptimizeLevel_OPTIMIZE_LOSSY:
+		args = append(args, "--optimize", "2")
+	case pb.OptimizeLevel_OPTIMIZE_AGGRESSIVE:
+		args = append(args, "--optimize", "3")
+	}
+
+	switch opts.GetOutputType() {
+	case pb.OcrOutputType_OCR_OUTPUT_PDF:
+		args = append(args, "--output-type", "pdf")
+	case pb.OcrOutputType_OCR_OUTPUT_PDFA:
+		args = append(args, "--output-type", "pdfa")
+	case pb.OcrOutputType_OCR_OUTPUT_PDFA_1:
+		args = append(args, "--output-type", "pdfa-1")
+	case pb.OcrOutputType_OCR_OUTPUT_PDFA_2:
+		args = append(args, "--output-type", "pdfa-2")
+	case pb.OcrOutputType_OCR_OUTPUT_PDFA_3:
+		args = append(args, "--output-type", "pdfa-3")
+	}
+
+	return args
+}
+
+// requestedPDFAPart returns the PDF/A part opts explicitly ask for, or 0 if
+// they don't ask for PDF/A.
+func requestedPDFAPart(opts *pb.OcrOptions) int {
+	switch opts.GetOutputType() {
+	case pb.OcrOutputType_OCR_OUTPUT_PDFA, pb.OcrOutputType_OCR_OUTPUT_PDFA_2:
+		return 2
+	case pb.OcrOutputType_OCR_OUTPUT_PDFA_1:
+		return 1
+	case pb.OcrOutputType_OCR_OUTPUT_PDFA_3:
+		return 3
+	}
+	return 0
+}
+
+var (
+	pdfaPartPattern        = regexp.MustCompile(`pdfaid:part(?:="|>)\s*(\d)`)
+	pdfaConformancePattern = regexp.MustCompile(`pdfaid:conformance(?:="|>)\s*([A-Za-z])`)
 )
 
-func isScannedPDF(path string) (bool, error) {
-	content, _, err := extractTextFromPDF(path)
+// claimedPDFALevel returns the PDF/A part and conformance the XMP metadata
+// of the PDF at path claims, e.g. PDF/A-2B, or an empty string if it claims
+// none. The claim is taken as it is, the file isn't validated against it.
+func claimedPDFALevel(path string) (level string, part int, err error) {
+	cmd := exec.Command("pdfinfo", "-meta", path)
+	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return false, err
+		return "", 0, fmt.Errorf("pdfinfo failed: %v\nOutput: %s", err, output)
+	}
+
+	partMatch := pdfaPartPattern.FindSubmatch(output)
+	if partMatch == nil {
+		return "", 0, nil
 	}
-	return len(strings.TrimSpace(string(content))) != 0, nil
+	part, _ = strconv.Atoi(string(partMatch[1]))
+
+	level = "PDF/A-" + string(partMatch[1])
+	if m := pdfaConformancePattern.FindSubmatch(output); m != nil {
+		level += strings.ToUpper(string(m[1]))
+	}
+	return level, part, nil
 }
 
-func runOCRMyPDF(inputPath string) error {
-	tempfile, err := os.CreateTemp("", "temp-ocr-*.pdf")
-	defer os.Remove(tempfile.Name())
+// siblingTempFile creates an empty, closed temporary file in the directory of
+// path, so a tool can write its output there and replaceFile can rename it
+// over path without copying the file through memory.
+func siblingTempFile(path, pattern string) (*os.File, error) {
+	tempfile, err := os.CreateTemp(filepath.Dir(path), pattern)
+	if err != nil {
+		return nil, err
+	}
+	return tempfile, tempfile.Close()
+}
+
+// replaceFile moves the processed file at tempPath over path.
+func replaceFile(tempPath, path string) error {
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("failed to overwrite input file: %v", err)
+	}
+	return nil
+}
+
+// runOCRMyPDF OCRs the given pages of the PDF at inputPath in place, as
+// configured by opts. If hocrDir is set, ocrmypdf keeps its temporary files,
+// including the hOCR of every OCRed page, in there for readOCRMyPDFHOCR.
+func runOCRMyPDF(inputPath string, languages []string, pages []int32, opts *pb.OcrOptions, hocrDir string) error {
+	tempfile, err := siblingTempFile(inputPath, "temp-ocr-*.pdf")
 	if err != nil {
 		return err
 	}
-	cmd := exec.Command("ocrmypdf", "--skip-text", inputPath, tempfile.Name())
+	defer os.Remove(tempfile.Name())
+
+	args := ocrMyPDFArgs(opts, pages)
+	if len(languages) > 0 {
+		args = append(args, "-l", strings.Join(languages, "+"))
+	}
+	if hocrDir != "" {
+		// The hocr renderer is the one that writes hOCR files. A retry after
+		// a repair must not pick up the files of the failed run.
+		args = append(args, "--keep-temporary-files", "--pdf-renderer", "hocr")
+		entries, err := os.ReadDir(hocrDir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			os.RemoveAll(filepath.Join(hocrDir, entry.Name()))
+		}
+	}
+	args = append(args, inputPath, tempfile.Name())
+
+	cmd := exec.Command("ocrmypdf", args...)
+	if hocrDir != "" {
+		// ocrmypdf creates its working directory in TMPDIR.
+		cmd.Env = append(os.Environ(), "TMPDIR="+hocrDir)
+	}
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("ocrmypdf failed: %v\nOutput: %s", err, output)
 	}
 
-	processedData, err := os.ReadFile(tempfile.Name())
+	return replaceFile(tempfile.Name(), inputPath)
+}
+
+func isEncrypted(pdfPath string) bool {
+	// qpdf exits with 0 if the file is encrypted and 2 if it is not.
+	cmd := exec.Command("qpdf", "--is-encrypted", pdfPath)
+	return cmd.Run() == nil
+}
+
+// qpdfPasswordArgs writes password to a temporary file only the service can
+// read and returns the qpdf arguments that read it from there, so the
+// password doesn't show up in the process list. remove deletes the file.
+func qpdfPasswordArgs(password string) (args []string, remove func(), err error) {
+	if password == "" {
+		return nil, func() {}, nil
+	}
+
+	// CreateTemp creates the file with mode 0600.
+	file, err := os.CreateTemp("", "pdf-password-*")
 	if err != nil {
-		return fmt.Errorf("failed to read processed file: %v", err)
+		return nil, nil, fmt.Errorf("failed to create password file: %v", err)
 	}
+	remove = func() { os.Remove(file.Name()) }
 
-	err = os.WriteFile(inputPath, processedData, 0644)
+	_, err = file.WriteString(password)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
-		return fmt.Errorf("failed to overwrite input file: %v", err)
+		remove()
+		return nil, nil, fmt.Errorf("failed to write password file: %v", err)
 	}
-	return nil
+	return []string{"--password-file=" + file.Name()}, remove, nil
 }
 
-func isEncrypted(pdfPath string) bool {
-	cmd := exec.Command("qpdf", "--check", pdfPath)
-	output, err := cmd.CombinedOutput()
+// checkPDFPassword returns a PermissionDenied error if the PDF at path can't
+// be opened without a password and password is missing or wrong.
+func checkPDFPassword(path, password string) error {
+	args, removePassword, err := qpdfPasswordArgs(password)
 	if err != nil {
-		return strings.Contains(string(output), "File is not encrypted")
+		return err
 	}
-	return false
+	defer removePassword()
+	args = append([]string{"--requires-password"}, append(args, path)...)
+
+	// qpdf exits with 0 if a password is still required, 2 if the file is
+	// not encrypted and 3 if it opens without one or with the given one.
+	cmd := exec.Command("qpdf", args...)
+	output, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil && password == "":
+		return status.Error(codes.PermissionDenied, "pdf is password protected, a password is required")
+	case err == nil:
+		return status.Error(codes.PermissionDenied, "incorrect pdf password")
+	case errors.As(err, &exitErr) && (exitErr.ExitCode() == 2 || exitErr.ExitCode() == 3):
+		return nil
+	}
+	return fmt.Errorf("qpdf failed: %v\nOutput: %s", err, output)
+}
+
+// pdfDamagePattern matches the messages qpdf, poppler and ghostscript print
+// for broken cross-reference tables, trailers or object syntax.
+var pdfDamagePattern = regexp.MustCompile(`(?i)syntax error|xref|trailer|damaged|reconstruct`)
+
+// isPDFDamage reports whether err, which carries the output of the failed
+// tool, points at structural damage of the PDF a repair may fix.
+func isPDFDamage(err error) bool {
+	return pdfDamagePattern.MatchString(err.Error())
 }
 
-func repairPDF(inputPath string) error {
-	tempfile, err := os.CreateTemp("", "")
+// repairPDF rewrites the damaged PDF at inputPath in place and reports if it
+// did. qpdf rebuilds broken cross-reference tables while reading the file, if
+// it reads the file without warnings there is nothing to repair. If qpdf
+// can't recover the file, ghostscript renders it into a new PDF instead.
+func repairPDF(inputPath string) (bool, error) {
+	tempfile, err := siblingTempFile(inputPath, "temp-repair-*.pdf")
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer os.Remove(tempfile.Name())
 
-	cmd := exec.Command("qpdf", "--repair", inputPath, tempfile.Name())
+	// qpdf exits with 3 if it wrote the file but had to warn about damage.
+	cmd := exec.Command("qpdf", inputPath, tempfile.Name())
 	output, err := cmd.CombinedOutput()
-	if err != nil {
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 3:
+	default:
+		gsCmd := exec.Command("gs", "-q", "-dBATCH", "-dNOPAUSE", "-dSAFER", "-sDEVICE=pdfwrite", "-o", tempfile.Name(), inputPath)
+		gsOutput, gsErr := gsCmd.CombinedOutput()
+		if gsErr != nil {
+			return false, fmt.Errorf("qpdf failed: %v\nOutput: %s\nghostscript failed: %v\nOutput: %s", err, output, gsErr, gsOutput)
+		}
+	}
+
+	return true, replaceFile(tempfile.Name(), inputPath)
+}
+
+// pdfRepair repairs a damaged PDF at most once and remembers if it did. The
+// PDF must not need a password, encrypted files are decrypted first.
+type pdfRepair struct {
+	path      string
+	attempted bool
+	repaired  bool
+}
+
+// retry runs fn and, if it fails because the PDF is damaged, repairs the PDF
+// and runs fn once more. Other errors, like a wrong password or a page past
+// the end, are returned as they are since a repair can't fix them.
+func (r *pdfRepair) retry(fn func() error) error {
+	err := fn()
+	if err == nil || r.attempted || !isPDFDamage(err) {
 		return err
 	}
+	r.attempted = true
 
-	_, err = tempfile.Write(output)
+	repaired, repairErr := repairPDF(r.path)
+	switch {
+	case repairErr != nil:
+		fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "Repair of", r.path, "failed:", repairErr)
+		return err
+	case !repaired:
+		fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "Found no damage to repair in", r.path)
+		return err
+	}
+	fmt.Println(time.Now().Format("2006-01-02 15:04:05.000"), "Repaired damaged pdf", r.path)
+	r.repaired = true
 
-	return err
+	return fn()
 }
 
-func decryptPDF(inputPath string) error {
-	tempfile, err := os.CreateTemp("", "")
+// imageToPDF replaces the image at inputPath with a PDF holding one page per
+// image frame, so multi-page TIFFs keep all their pages. Photos are rotated
+// upright by their EXIF orientation and transparency is flattened onto white.
+func imageToPDF(inputPath string) error {
+	tempfile, err := siblingTempFile(inputPath, "temp-image-*.pdf")
 	if err != nil {
 		return err
 	}
 	defer os.Remove(tempfile.Name())
 
-	cmd := exec.Command("qpdf", "--decrypt", inputPath, tempfile.Name())
+	cmd := exec.Command("magick", inputPath,
+		"-auto-orient",
+		"-background", "white",
+		"-alpha", "remove",
+		"-alpha", "off",
+		tempfile.Name())
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("qpdf failed: %v\nOutput: %s", err, output)
+		return fmt.Errorf("magick failed: %v\nOutput: %s", err, output)
 	}
 
-	processedData, err := os.ReadFile(tempfile.Name())
+	return replaceFile(tempfile.Name(), inputPath)
+}
+
+// decryptPDF decrypts the PDF at inputPath in place.
+func decryptPDF(inputPath, password string) error {
+	decrypted, err := decryptedCopy(inputPath, password)
 	if err != nil {
-		return fmt.Errorf("failed to read processed file: %v", err)
+		return err
 	}
+	return replaceFile(decrypted, inputPath)
+}
 
-	err = os.WriteFile(inputPath, processedData, 0644)
+// decryptedCopy writes a decrypted copy of the PDF at inputPath next to it
+// and returns its path. The poppler tools only ever read decrypted files, so
+// the password is handed to qpdf alone and never shows up in their arguments.
+func decryptedCopy(inputPath, password string) (string, error) {
+	tempfile, err := siblingTempFile(inputPath, "temp-decrypt-*.pdf")
 	if err != nil {
-		return fmt.Errorf("failed to overwrite input file: %v", err)
+		return "", err
 	}
-	return nil
-}
 
-func extractTextFromPDF(path string) (string, []byte, error) {
+	args, removePassword, err := qpdfPasswordArgs(password)
+	if err != nil {
+		os.Remove(tempfile.Name())
+		return "", err
+	}
+	defer removePassword()
+	args = append([]string{"--decrypt"}, append(args, inputPath, tempfile.Name())...)
+
+	// qpdf exits with 3 if it wrote the file but had to warn about damage.
+	cmd := exec.Command("qpdf", args...)
+	output, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 3) {
+		os.Remove(tempfile.Name())
+		return "", fmt.Errorf("qpdf failed: %v\nOutput: %s", err, output)
+	}
+
+	return tempfile.Name(), nil
+}
 
+func extractTextFromPDF(path string) (string, error) {
 	tmpOut, err := os.CreateTemp("", "pdftotext-*.txt")
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to create temp file: %w", err)
+		return "", fmt.Errorf("failed to create temp file: %w", err)
 	}
 	tmpOut.Close()
 	defer os.Remove(tmpOut.Name())
@@ -105,71 +448,71 @@ func extractTextFromPDF(path string) (string, []byte, error) {
 	cmd := exec.Command("pdftotext", path, tmpOut.Name())
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", nil, fmt.Errorf("pdftotext failed: %v\nOutput: %s", err, output)
+		return "", fmt.Errorf("pdftotext failed: %v\nOutput: %s", err, output)
 	}
 
 	data, err := os.ReadFile(tmpOut.Name())
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to read pdftotext output: %w", err)
+		return "", fmt.Errorf("failed to read pdftotext output: %w", err)
 	}
 
-	rawData, err := os.ReadFile(path)
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to read raw PDF file: %w", err)
-	}
-
-	return string(data), rawData, nil
+	return string(data), nil
 }
 
-func isUselessLine(line string) bool {
-	if len(line) == 0 {
-		return true
-	}
-
-	firstChar := rune(line[0])
-	if !unicode.IsLetter(firstChar) && !unicode.IsNumber(firstChar) {
-		allSame := true
-		for _, c := range line {
-			if c != firstChar {
-				allSame = false
-				break
-			}
-		}
-		if allSame && len(line) > 3 { // Minimum 4 repeating chars to consider useless
-			return true
-		}
-	}
-
-	return false
+// splitPages splits text extracted by pdftotext into the text of every page.
+// pdftotext ends every page, including the last one, with a form feed.
+func splitPages(text string) []string {
+	return strings.Split(strings.TrimSuffix(text, "\f"), "\f")
 }
 
-func cleanOCRText(input string) string {
-	var builder strings.Builder
-	builder.Grow(len(input))
+// pdfInfo reads the document metadata and the size of every page of the PDF
+// at path using pdfinfo.
+func pdfInfo(path string) (*pb.PdfInfo, error) {
+	// pdfinfo caps the last page at the page count of the document.
+	cmd := exec.Command("pdfinfo", "-f", "1", "-l", strconv.Itoa(math.MaxInt32), path)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("pdfinfo failed: %v\nOutput: %s", err, output)
+	}
 
-	scanner := bufio.NewScanner(strings.NewReader(input))
+	info := &pb.PdfInfo{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		if isUselessLine(line) {
-			builder.WriteRune(' ')
+		line := scanner.Text()
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
 			continue
 		}
+		value = strings.TrimSpace(value)
 
-		prevSpace := false
-		for _, r := range line {
-			if unicode.IsSpace(r) {
-				if !prevSpace {
-					builder.WriteRune(' ')
-					prevSpace = true
-				}
-			} else {
-				builder.WriteRune(r)
-				prevSpace = false
+		switch key {
+		case "Title":
+			info.Title = value
+		case "Author":
+			info.Author = value
+		case "Creator":
+			info.Creator = value
+		case "Producer":
+			info.Producer = value
+		case "PDF version":
+			info.PdfVersion = value
+		case "Encrypted":
+			info.Encrypted = strings.HasPrefix(value, "yes")
+		case "Pages":
+			pages, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse page count %q: %v", value, err)
+			}
+			info.PageCount = int32(pages)
+		default:
+			// Page    1 size: 612 x 792 pts (letter)
+			var page int
+			var width, height float64
+			if _, err := fmt.Sscanf(line, "Page %d size: %g x %g", &page, &width, &height); err == nil {
+				info.PageSizes = append(info.PageSizes, &pb.PdfPageSize{Page: int32(page), Width: width, Height: height})
 			}
 		}
 	}
 
-	cleaned := strings.TrimSpace(builder.String())
-	return cleaned
+	return info, nil
 }
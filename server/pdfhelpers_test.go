@@ -0,0 +1,64 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	pb "github.com/JuLi0n21/thumbnail_service/proto"
+)
+
+func TestOcrTargetPages(t *testing.T) {
+	pageTexts := []string{
+		"",
+		"  Page 2  \n",
+		"A born-digital page with plenty of text on it.",
+		"Bates ID 0000123",
+	}
+
+	tests := []struct {
+		mode pb.OcrMode
+		want []int32
+	}{
+		{pb.OcrMode_OCR_MODE_AUTO, []int32{1, 2, 4}},
+		{pb.OcrMode_SKIP_TEXT, []int32{1}},
+		{pb.OcrMode_FORCE_OCR, []int32{1, 2, 3, 4}},
+		{pb.OcrMode_REDO_OCR, []int32{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			got := ocrTargetPages(&pb.OcrOptions{Mode: tt.mode}, pageTexts)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ocrTargetPages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOcrMyPDFArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  *pb.OcrOptions
+		pages []int32
+		want  []string
+	}{
+		{"auto", nil, []int32{1, 4}, []string{"--force-ocr", "--pages", "1,4"}},
+		{"auto without pages", nil, nil, []string{"--skip-text"}},
+		{"skip text", &pb.OcrOptions{Mode: pb.OcrMode_SKIP_TEXT}, []int32{1}, []string{"--skip-text"}},
+		{"redo", &pb.OcrOptions{Mode: pb.OcrMode_REDO_OCR, Clean: true}, []int32{1}, []string{"--redo-ocr", "--clean"}},
+		{
+			"pdfa",
+			&pb.OcrOptions{Mode: pb.OcrMode_FORCE_OCR, Optimize: pb.OptimizeLevel_OPTIMIZE_LOSSY, OutputType: pb.OcrOutputType_OCR_OUTPUT_PDFA_3},
+			[]int32{1},
+			[]string{"--force-ocr", "--optimize", "2", "--output-type", "pdfa-3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ocrMyPDFArgs(tt.opts, tt.pages); !slices.Equal(got, tt.want) {
+				t.Errorf("ocrMyPDFArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
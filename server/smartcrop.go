@@ -0,0 +1,147 @@
+package main
+
+import (
+	"image"
+	"math"
+
+	pb "github.com/JuLi0n21/thumbnail_service/proto"
+	"github.com/nfnt/resize"
+)
+
+// smartCropAnalysisSize is the longer side the image is scaled down to before
+// it is scored, which keeps the analysis fast for large photos and frames.
+const smartCropAnalysisSize = 256
+
+// focalCrop returns the largest rectangle with the aspect ratio of maxWidth
+// and maxHeight centered on the focal point as far as bounds allow.
+func focalCrop(bounds image.Rectangle, maxWidth, maxHeight int, focal *pb.FocalPoint) image.Rectangle {
+	crop := centerCrop(bounds, maxWidth, maxHeight)
+
+	cx := bounds.Min.X + int(float64(focal.X)*float64(bounds.Dx()))
+	cy := bounds.Min.Y + int(float64(focal.Y)*float64(bounds.Dy()))
+
+	x := clamp(cx-crop.Dx()/2, bounds.Min.X, bounds.Max.X-crop.Dx())
+	y := clamp(cy-crop.Dy()/2, bounds.Min.Y, bounds.Max.Y-crop.Dy())
+	return image.Rect(x, y, x+crop.Dx(), y+crop.Dy())
+}
+
+// smartCrop returns the largest rectangle with the aspect ratio of maxWidth
+// and maxHeight that covers the most interesting region of img.
+//
+// Every pixel of a downscaled copy is scored by its edge strength, color
+// saturation and whether it looks like skin; the crop window is then slid
+// along the free axis and the position with the highest total score wins.
+func smartCrop(img image.Image, maxWidth, maxHeight int) image.Rectangle {
+	bounds := img.Bounds()
+	crop := centerCrop(bounds, maxWidth, maxHeight)
+	if crop.Dx() == bounds.Dx() && crop.Dy() == bounds.Dy() {
+		return crop
+	}
+
+	scale := float64(smartCropAnalysisSize) / float64(max(bounds.Dx(), bounds.Dy()))
+	if scale > 1 {
+		scale = 1
+	}
+	w := max(1, int(float64(bounds.Dx())*scale))
+	h := max(1, int(float64(bounds.Dy())*scale))
+	small := resize.Resize(uint(w), uint(h), img, resize.Bilinear)
+
+	scores := interestScores(small)
+
+	// Sum the scores per column or row, the window only moves along one axis.
+	horizontal := crop.Dx() < bounds.Dx()
+	n := h
+	if horizontal {
+		n = w
+	}
+	sums := make([]float64, n+1)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := y
+			if horizontal {
+				i = x
+			}
+			sums[i+1] += scores[y*w+x]
+		}
+	}
+	for i := 1; i <= n; i++ {
+		sums[i] += sums[i-1]
+	}
+
+	window := int(math.Round(float64(crop.Dy()) * scale))
+	if horizontal {
+		window = int(math.Round(float64(crop.Dx()) * scale))
+	}
+	window = clamp(window, 1, n)
+
+	best, bestScore := 0, -1.0
+	for start := 0; start+window <= n; start++ {
+		score := sums[start+window] - sums[start]
+		if score > bestScore {
+			best, bestScore = start, score
+		}
+	}
+
+	offset := int(float64(best) / scale)
+	if horizontal {
+		x := clamp(bounds.Min.X+offset, bounds.Min.X, bounds.Max.X-crop.Dx())
+		return image.Rect(x, crop.Min.Y, x+crop.Dx(), crop.Max.Y)
+	}
+	y := clamp(bounds.Min.Y+offset, bounds.Min.Y, bounds.Max.Y-crop.Dy())
+	return image.Rect(crop.Min.X, y, crop.Max.X, y+crop.Dy())
+}
+
+// interestScores returns a score per pixel of img in row-major order.
+func interestScores(img image.Image) []float64 {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	luma := make([]float64, w*h)
+	scores := make([]float64, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			rf, gf, bf := float64(r)/0xffff, float64(g)/0xffff, float64(b)/0xffff
+			luma[y*w+x] = 0.299*rf + 0.587*gf + 0.114*bf
+
+			maxC := math.Max(rf, math.Max(gf, bf))
+			minC := math.Min(rf, math.Min(gf, bf))
+			saturation := 0.0
+			if maxC > 0 {
+				saturation = (maxC - minC) / maxC
+			}
+			scores[y*w+x] = 0.2*saturation + skinScore(rf, gf, bf)
+		}
+	}
+
+	// Sobel edge magnitude on the luma channel.
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			at := func(dx, dy int) float64 { return luma[(y+dy)*w+x+dx] }
+			gx := at(1, -1) + 2*at(1, 0) + at(1, 1) - at(-1, -1) - 2*at(-1, 0) - at(-1, 1)
+			gy := at(-1, 1) + 2*at(0, 1) + at(1, 1) - at(-1, -1) - 2*at(0, -1) - at(1, -1)
+			scores[y*w+x] += math.Hypot(gx, gy)
+		}
+	}
+
+	return scores
+}
+
+// skinScore rates how close a color is to common skin tones, so faces weigh
+// more than equally detailed backgrounds.
+func skinScore(r, g, b float64) float64 {
+	if r <= g || r <= b || r < 0.35 || r-math.Min(g, b) < 0.06 {
+		return 0
+	}
+	sum := r + g + b
+	rn, gn := r/sum, g/sum
+	d := math.Hypot(rn-0.44, gn-0.31)
+	if d > 0.08 {
+		return 0
+	}
+	return 0.5 * (1 - d/0.08)
+}
+
+func clamp(v, lo, hi int) int {
+	return min(max(v, lo), hi)
+}
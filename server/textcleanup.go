@@ -0,0 +1,252 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	pb "github.com/JuLi0n21/thumbnail_service/proto"
+	"golang.org/x/text/unicode/norm"
+)
+
+// minRunningLinePages is the smallest document running headers and footers
+// are looked for in, fewer pages repeat lines by chance too easily.
+const minRunningLinePages = 3
+
+// ligatures maps typographic ligatures to the letters they stand for.
+var ligatures = strings.NewReplacer(
+	"ﬀ", "ff",
+	"ﬁ", "fi",
+	"ﬂ", "fl",
+	"ﬃ", "ffi",
+	"ﬄ", "ffl",
+	"ﬅ", "st",
+	"ﬆ", "st",
+	"Ĳ", "IJ",
+	"ĳ", "ij",
+)
+
+var digitsPattern = regexp.MustCompile(`\d+`)
+
+// cleanupOptions returns the cleanup stages req asks for, mapping the
+// deprecated cleanUp flag to the stages it used to run.
+func cleanupOptions(req *pb.OCRFileRequest) *pb.CleanupOptions {
+	if req.CleanupOptions != nil {
+		return req.CleanupOptions
+	}
+	if req.CleanUp {
+		return &pb.CleanupOptions{RemoveSeparatorLines: true, CollapseWhitespace: true}
+	}
+	return nil
+}
+
+// cleanText cleans up text extracted from a whole document, whose pages are
+// separated by form feeds as pdftotext writes them.
+func cleanText(text string, opts *pb.CleanupOptions) string {
+	pages := cleanPages(splitPages(text), opts)
+	if !opts.CollapseWhitespace || opts.PreserveParagraphs {
+		return strings.TrimSpace(strings.Join(pages, "\f"))
+	}
+
+	// Without page breaks, empty pages would only leave double spaces.
+	nonEmpty := pages[:0]
+	for _, page := range pages {
+		if page != "" {
+			nonEmpty = append(nonEmpty, page)
+		}
+	}
+	return strings.Join(nonEmpty, " ")
+}
+
+// cleanPages runs the cleanup stages of opts on the text of every page.
+func cleanPages(pages []string, opts *pb.CleanupOptions) []string {
+	lines := make([][]string, len(pages))
+	for i, page := range pages {
+		if opts.StripControlCharacters {
+			page = stripControlCharacters(page)
+		}
+		if opts.NormalizeUnicode {
+			page = norm.NFKC.String(page)
+		}
+		if opts.ExpandLigatures {
+			page = ligatures.Replace(page)
+		}
+		lines[i] = strings.Split(page, "\n")
+	}
+
+	if opts.RemoveHeadersFooters {
+		removeRunningLines(lines)
+	}
+
+	cleaned := make([]string, len(pages))
+	for i, pageLines := range lines {
+		if opts.RemoveSeparatorLines {
+			pageLines = removeSeparatorLines(pageLines)
+		}
+		if opts.Dehyphenate {
+			pageLines = dehyphenate(pageLines)
+		}
+		if opts.CollapseWhitespace {
+			cleaned[i] = collapseWhitespace(pageLines, opts.PreserveParagraphs)
+		} else {
+			cleaned[i] = strings.Join(pageLines, "\n")
+		}
+	}
+	return cleaned
+}
+
+// stripControlCharacters removes control and format characters, keeping line
+// breaks and tabs.
+func stripControlCharacters(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// removeRunningLines blanks the first and last non-empty line of every page
+// if it repeats on at least half of the pages. Numbers are ignored when lines
+// are compared, so running lines with page numbers are found as well. Pages
+// keep at least one line, a page with a single line only holds body text.
+func removeRunningLines(pages [][]string) {
+	if len(pages) < minRunningLinePages {
+		return
+	}
+
+	first := make([]int, len(pages))
+	last := make([]int, len(pages))
+	nonEmpty := make([]int, len(pages))
+	counts := map[string]int{}
+	for i, lines := range pages {
+		first[i], last[i] = -1, -1
+		for j, line := range lines {
+			if strings.TrimSpace(line) != "" {
+				if first[i] < 0 {
+					first[i] = j
+				}
+				last[i] = j
+				nonEmpty[i]++
+			}
+		}
+		if nonEmpty[i] < 2 {
+			continue
+		}
+
+		counts["h"+runningLineKey(lines[first[i]])]++
+		counts["f"+runningLineKey(lines[last[i]])]++
+	}
+
+	threshold := (len(pages) + 1) / 2
+	for i, lines := range pages {
+		if nonEmpty[i] < 2 {
+			continue
+		}
+		header := counts["h"+runningLineKey(lines[first[i]])] >= threshold
+		footer := counts["f"+runningLineKey(lines[last[i]])] >= threshold
+		if header && footer && nonEmpty[i] == 2 {
+			// Blanking both lines would leave the page without body text.
+			continue
+		}
+		if header {
+			lines[first[i]] = ""
+		}
+		if footer {
+			lines[last[i]] = ""
+		}
+	}
+}
+
+func runningLineKey(line string) string {
+	return digitsPattern.ReplaceAllString(strings.Join(strings.Fields(line), " "), "#")
+}
+
+func removeSeparatorLines(lines []string) []string {
+	kept := lines[:0]
+	for _, line := range lines {
+		if trimmed := strings.TrimSpace(line); trimmed != "" && isUselessLine(trimmed) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return kept
+}
+
+// isUselessLine reports whether line consists of a single repeated
+// punctuation or symbol character, like a ---- separator.
+func isUselessLine(line string) bool {
+	if len(line) == 0 {
+		return true
+	}
+
+	firstChar := rune(line[0])
+	if !unicode.IsLetter(firstChar) && !unicode.IsNumber(firstChar) {
+		allSame := true
+		for _, c := range line {
+			if c != firstChar {
+				allSame = false
+				break
+			}
+		}
+		if allSame && len(line) > 3 { // Minimum 4 repeating chars to consider useless
+			return true
+		}
+	}
+
+	return false
+}
+
+// dehyphenate joins words split by a hyphen at the end of a line with their
+// remainder at the start of the next line. A hyphen is only dropped if it
+// follows a letter and the next line starts with a lowercase letter, so
+// compound words like "well-\nKnown" keep theirs.
+func dehyphenate(lines []string) []string {
+	joined := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRightFunc(lines[i], unicode.IsSpace)
+		for i+1 < len(lines) && endsWithHyphenatedWord(line) {
+			next := strings.TrimLeftFunc(lines[i+1], unicode.IsSpace)
+			first := []rune(next)
+			if len(first) == 0 || !unicode.IsLower(first[0]) {
+				break
+			}
+			line = strings.TrimSuffix(line, "-") + strings.TrimRightFunc(next, unicode.IsSpace)
+			i++
+		}
+		joined = append(joined, line)
+	}
+	return joined
+}
+
+func endsWithHyphenatedWord(line string) bool {
+	runes := []rune(line)
+	return len(runes) >= 2 && runes[len(runes)-1] == '-' && unicode.IsLetter(runes[len(runes)-2])
+}
+
+// collapseWhitespace collapses runs of whitespace into single spaces and
+// joins the lines. With preserveParagraphs, only the lines of a paragraph are
+// joined and paragraphs, separated by blank lines, stay apart.
+func collapseWhitespace(lines []string, preserveParagraphs bool) string {
+	var paragraphs []string
+	var current []string
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			if preserveParagraphs && len(current) > 0 {
+				paragraphs = append(paragraphs, strings.Join(current, " "))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, strings.Join(fields, " "))
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, strings.Join(current, " "))
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"testing"
+
+	pb "github.com/JuLi0n21/thumbnail_service/proto"
+)
+
+func TestDehyphenate(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []string
+	}{
+		{"split word", []string{"infor-", "mation retrieval"}, []string{"information retrieval"}},
+		{"trailing space", []string{"infor-  ", "  mation"}, []string{"information"}},
+		{"several lines", []string{"a com-", "pli-", "cated word"}, []string{"a complicated word"}},
+		{"capitalized next line", []string{"well-", "Known"}, []string{"well-", "Known"}},
+		{"dash after number", []string{"pages 1-", "3"}, []string{"pages 1-", "3"}},
+		{"dash on its own", []string{"text -", "more"}, []string{"text -", "more"}},
+		{"last line", []string{"end-"}, []string{"end-"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dehyphenate(tt.lines)
+			if len(got) != len(tt.want) {
+				t.Fatalf("dehyphenate(%q) = %q, want %q", tt.lines, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("dehyphenate(%q) = %q, want %q", tt.lines, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCollapseWhitespace(t *testing.T) {
+	lines := []string{"  first   line", "second\tline", "", "   ", "next  paragraph", ""}
+
+	tests := []struct {
+		name               string
+		preserveParagraphs bool
+		want               string
+	}{
+		{"joined", false, "first line second line next paragraph"},
+		{"paragraphs preserved", true, "first line second line\n\nnext paragraph"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collapseWhitespace(lines, tt.preserveParagraphs); got != tt.want {
+				t.Errorf("collapseWhitespace() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanupOptions(t *testing.T) {
+	if opts := cleanupOptions(&pb.OCRFileRequest{}); opts != nil {
+		t.Errorf("cleanupOptions() without cleanup = %v, want nil", opts)
+	}
+
+	legacy := cleanupOptions(&pb.OCRFileRequest{CleanUp: true})
+	if legacy == nil || !legacy.RemoveSeparatorLines || !legacy.CollapseWhitespace {
+		t.Fatalf("cleanupOptions() for cleanUp = %v, want separator removal and whitespace collapsing", legacy)
+	}
+	if legacy.StripControlCharacters || legacy.NormalizeUnicode || legacy.ExpandLigatures ||
+		legacy.RemoveHeadersFooters || legacy.Dehyphenate || legacy.PreserveParagraphs {
+		t.Errorf("cleanupOptions() for cleanUp = %v, want only the stages cleanUp used to run", legacy)
+	}
+
+	explicit := &pb.CleanupOptions{Dehyphenate: true}
+	if opts := cleanupOptions(&pb.OCRFileRequest{CleanUp: true, CleanupOptions: explicit}); opts != explicit {
+		t.Errorf("cleanupOptions() = %v, want the cleanup_options of the request %v", opts, explicit)
+	}
+}
+
+func TestCleanTextLegacy(t *testing.T) {
+	text := "Title\n-----\nsome   text\f\nmore text\n\f"
+	want := "Title some text more text"
+
+	if got := cleanText(text, cleanupOptions(&pb.OCRFileRequest{CleanUp: true})); got != want {
+		t.Errorf("cleanText() = %q, want %q", got, want)
+	}
+}
+
+func TestCleanTextCollapseWhitespace(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *pb.CleanupOptions
+		want string
+	}{
+		{"joined", &pb.CleanupOptions{CollapseWhitespace: true}, "a b"},
+		{"paragraphs preserved", &pb.CleanupOptions{CollapseWhitespace: true, PreserveParagraphs: true}, "a\f\fb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanText("a\f \n\fb\f", tt.opts); got != tt.want {
+				t.Errorf("cleanText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanTextRemoveHeadersFooters(t *testing.T) {
+	opts := &pb.CleanupOptions{RemoveHeadersFooters: true}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			"running lines with page numbers",
+			"Report\nfirst page\nPage 1\fReport\nsecond page\nPage 2\fReport\nthird page\nPage 3\f",
+			"first page\n\f\nsecond page\n\f\nthird page",
+		},
+		{
+			"single line pages",
+			"Same\fSame\fSame\f",
+			"Same\fSame\fSame",
+		},
+		{
+			"two line pages keep their body",
+			"Report\nPage 1\fReport\nPage 2\fReport\nPage 3\f",
+			"Report\nPage 1\fReport\nPage 2\fReport\nPage 3",
+		},
+		{
+			"too few pages",
+			"Report\nbody\fReport\nbody\f",
+			"Report\nbody\fReport\nbody",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanText(tt.text, opts); got != tt.want {
+				t.Errorf("cleanText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/JuLi0n21/thumbnail_service/proto"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultFrameWindow is the length in seconds of a best frame window
+	// that leaves the duration unset.
+	defaultFrameWindow = 10.0
+
+	defaultStoryboardInterval  = 10.0
+	defaultStoryboardTileWidth = 160
+	defaultStoryboardGrid      = 10
+
+	defaultPreviewFrames = 10
+	defaultPreviewDelay  = 500 // milliseconds
+)
+
+// videoDuration returns the duration of the video at path in seconds.
+func videoDuration(path string) (float64, error) {
+	cmd := exec.Command("ffprobe", "-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		path)
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("ffprobe failed: %v", err)
+	}
+
+	duration, err := strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse video duration %q: %v", strings.TrimSpace(string(output)), err)
+	}
+	return duration, nil
+}
+
+// probeVideo reads the container and stream metadata of the video at path
+// using ffprobe.
+func probeVideo(path string) (*pb.VideoInfo, error) {
+	cmd := exec.Command("ffprobe", "-v", "error",
+		"-print_format", "json",
+		"-show_format", "-show_streams",
+		path)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("ffprobe failed: %v", err)
+	}
+
+	var probe struct {
+		Format struct {
+			FormatName string `json:"format_name"`
+			Duration   string `json:"duration"`
+			BitRate    string `json:"bit_rate"`
+		} `json:"format"`
+		Streams []struct {
+			CodecType    string            `json:"codec_type"`
+			CodecName    string            `json:"codec_name"`
+			Width        int32             `json:"width"`
+			Height       int32             `json:"height"`
+			AvgFrameRate string            `json:"avg_frame_rate"`
+			Tags         map[string]string `json:"tags"`
+			SideDataList []struct {
+				Rotation float64 `json:"rotation"`
+			} `json:"side_data_list"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal(output, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse ffprobe output: %v", err)
+	}
+
+	info := &pb.VideoInfo{Container: probe.Format.FormatName}
+	info.Duration, _ = strconv.ParseFloat(probe.Format.Duration, 64)
+	info.BitRate, _ = strconv.ParseInt(probe.Format.BitRate, 10, 64)
+
+	videoFound := false
+	for _, stream := range probe.Streams {
+		switch {
+		case stream.CodecType == "video" && !videoFound:
+			videoFound = true
+			info.VideoCodec = stream.CodecName
+			info.Width = stream.Width
+			info.Height = stream.Height
+			info.FrameRate = parseFrameRate(stream.AvgFrameRate)
+
+			// Older FFmpeg versions report the rotation as a tag, newer
+			// ones as display matrix side data.
+			if rotate, err := strconv.Atoi(stream.Tags["rotate"]); err == nil {
+				info.Rotation = int32(rotate)
+			}
+			for _, side := range stream.SideDataList {
+				if side.Rotation != 0 {
+					info.Rotation = int32(side.Rotation)
+				}
+			}
+		case stream.CodecType == "audio" && info.AudioCodec == "":
+			info.AudioCodec = stream.CodecName
+		}
+	}
+	if !videoFound {
+		return nil, errors.New("file contains no video stream")
+	}
+
+	return info, nil
+}
+
+// parseFrameRate parses rates like 30000/1001 as reported by ffprobe.
+func parseFrameRate(rate string) float64 {
+	num, den, ok := strings.Cut(rate, "/")
+	if !ok {
+		f, _ := strconv.ParseFloat(rate, 64)
+		return f
+	}
+	n, err1 := strconv.ParseFloat(num, 64)
+	d, err2 := strconv.ParseFloat(den, 64)
+	if err1 != nil || err2 != nil || d == 0 {
+		return 0
+	}
+	return n / d
+}
+
+func validateFrameSelection(frame *pb.VideoFrameSelection) error {
+	switch sel := frame.GetSelection().(type) {
+	case *pb.VideoFrameSelection_Timestamp:
+		if sel.Timestamp < 0 {
+			return status.Errorf(codes.InvalidArgument, "timestamp must not be negative, got %v", sel.Timestamp)
+		}
+	case *pb.VideoFrameSelection_Percentage:
+		if sel.Percentage < 0 || sel.Percentage > 100 {
+			return status.Errorf(codes.InvalidArgument, "percentage must be between 0 and 100, got %v", sel.Percentage)
+		}
+	case *pb.VideoFrameSelection_BestInWindow:
+		if sel.BestInWindow.Start < 0 || sel.BestInWindow.Duration < 0 {
+			return status.Error(codes.InvalidArgument, "frame window start and duration must not be negative")
+		}
+	}
+	return nil
+}
+
+// frameSelectionArgs returns the FFmpeg arguments that select the frame of
+// the video at inputPath, including the input itself. Seeking options are
+// placed before -i so FFmpeg jumps to the nearest keyframe instead of
+// decoding the video from the start.
+func frameSelectionArgs(inputPath string, frame *pb.VideoFrameSelection) ([]string, error) {
+	switch sel := frame.GetSelection().(type) {
+	case *pb.VideoFrameSelection_Timestamp:
+		return []string{"-ss", formatSeconds(sel.Timestamp), "-i", inputPath}, nil
+	case *pb.VideoFrameSelection_Percentage:
+		duration, err := videoDuration(inputPath)
+		if err != nil {
+			return nil, err
+		}
+		position := duration * sel.Percentage / 100
+		// The very end of a video has no frame left to decode.
+		position = min(position, max(0, duration-0.1))
+		return []string{"-ss", formatSeconds(position), "-i", inputPath}, nil
+	case *pb.VideoFrameSelection_BestInWindow:
+		window := sel.BestInWindow.Duration
+		if window == 0 {
+			window = defaultFrameWindow
+		}
+		return []string{
+			"-ss", formatSeconds(sel.BestInWindow.Start),
+			"-t", formatSeconds(window),
+			"-i", inputPath,
+			"-vf", "thumbnail",
+		}, nil
+	default:
+		return []string{"-i", inputPath, "-vf", "thumbnail"}, nil
+	}
+}
+
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
+
+// generateStoryboard samples a frame every interval seconds of the video at
+// inputPath and packs the frames into sprite images together with a WebVTT
+// index that maps every time range to its tile.
+func generateStoryboard(inputPath string, storyboard *pb.StoryboardOptions, opts imageOptions) (*pb.Storyboard, error) {
+	interval := storyboard.Interval
+	if interval == 0 {
+		interval = defaultStoryboardInterval
+	}
+	tileWidth := int(storyboard.TileWidth)
+	if tileWidth == 0 {
+		tileWidth = defaultStoryboardTileWidth
+	}
+	columns := int(storyboard.Columns)
+	if columns == 0 {
+		columns = defaultStoryboardGrid
+	}
+	rows := int(storyboard.Rows)
+	if rows == 0 {
+		rows = defaultStoryboardGrid
+	}
+	if interval < 0 || tileWidth < 0 || columns < 0 || rows < 0 {
+		return nil, status.Error(codes.InvalidArgument, "storyboard interval, tile width, columns and rows must not be negative")
+	}
+
+	frames, cleanup, err := extractVideoFrames(inputPath, fmt.Sprintf("fps=1/%s", formatSeconds(interval)))
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	// All tiles share the size of the first one so the sprite coordinates
+	// can be computed from the tile index alone.
+	tiles := make([]image.Image, 0, len(frames))
+	tileHeight := 0
+	for _, frame := range frames {
+		img, err := decodeImageFile(frame)
+		if err != nil {
+			return nil, err
+		}
+		if tileHeight == 0 {
+			tileHeight = scaleDimension(img.Bounds().Dy(), tileWidth, img.Bounds().Dx())
+		}
+		tile, _ := fitImage(img, tileWidth, tileHeight, imageOptions{fitMode: pb.FitMode_FILL})
+		tiles = append(tiles, tile)
+	}
+
+	perSprite := columns * rows
+	result := &pb.Storyboard{}
+	var vtt strings.Builder
+	vtt.WriteString("WEBVTT\n")
+
+	for first := 0; first < len(tiles); first += perSprite {
+		spriteTiles := tiles[first:min(first+perSprite, len(tiles))]
+		name := fmt.Sprintf("storyboard-%d%s", len(result.Sprites), fileExtension(opts.format))
+
+		sprite, err := encodeSprite(tileImages(spriteTiles, columns, 0, color.Black), name, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Sprites = append(result.Sprites, sprite)
+
+		for i := range spriteTiles {
+			start := float64(first+i) * interval
+			x := (i % columns) * tileWidth
+			y := (i / columns) * tileHeight
+			fmt.Fprintf(&vtt, "\n%s --> %s\n%s#xywh=%d,%d,%d,%d\n",
+				formatVttTime(start), formatVttTime(start+interval), name, x, y, tileWidth, tileHeight)
+		}
+	}
+
+	result.Vtt = vtt.String()
+	return result, nil
+}
+
+// generateAnimatedPreview samples frames evenly across the video at inputPath,
+// fits them into maxWidth and maxHeight and writes them as a looping GIF to
+// outputPath. It returns the region of the frames that was cropped.
+func generateAnimatedPreview(inputPath, outputPath string, preview *pb.AnimatedPreviewOptions, maxWidth, maxHeight int, opts imageOptions) (image.Rectangle, error) {
+	frameCount := int(preview.FrameCount)
+	if frameCount == 0 {
+		frameCount = defaultPreviewFrames
+	}
+	delay := int(preview.Delay)
+	if delay == 0 {
+		delay = defaultPreviewDelay
+	}
+	if frameCount < 0 || delay < 0 {
+		return image.Rectangle{}, status.Error(codes.InvalidArgument, "animated preview frame count and delay must not be negative")
+	}
+
+	duration, err := videoDuration(inputPath)
+	if err != nil {
+		return image.Rectangle{}, err
+	}
+	if duration <= 0 {
+		return image.Rectangle{}, errors.New("failed to generate animated preview: video has no duration")
+	}
+
+	frames, cleanup, err := extractVideoFrames(inputPath, fmt.Sprintf("fps=%s/%s", strconv.Itoa(frameCount), formatSeconds(duration)))
+	if err != nil {
+		return image.Rectangle{}, err
+	}
+	defer cleanup()
+	frames = frames[:min(frameCount, len(frames))]
+
+	images := make([]image.Image, 0, len(frames))
+	delays := make([]int, 0, len(frames))
+	for _, frame := range frames {
+		img, err := decodeImageFile(frame)
+		if err != nil {
+			return image.Rectangle{}, err
+		}
+
+		// The crop of the first frame is reused so the preview does not jump
+		// around, which a smart crop per frame would do.
+		resized, crop := fitImage(img, maxWidth, maxHeight, opts)
+		opts.crop = crop
+
+		images = append(images, resized)
+		delays = append(delays, max(1, delay/10))
+	}
+
+	return opts.crop, encodeAnimatedGif(outputPath, images, delays, 0)
+}
+
+// extractVideoFrames runs the video at inputPath through the FFmpeg filter
+// and writes every resulting frame as JPEG into a temporary directory. It
+// returns the frame paths in order and a function removing the directory.
+func extractVideoFrames(inputPath, filter string) ([]string, func(), error) {
+	dir, err := os.MkdirTemp("", "frames-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create temporary directory: %v", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	cmd := exec.Command("ffmpeg", "-y", "-i", inputPath, "-vf", filter, "-q:v", "2", filepath.Join(dir, "frame-%06d.jpg"))
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to extract video frames using FFmpeg: %v. FFmpeg stderr: %s", err, stderr.String())
+	}
+
+	frames, err := filepath.Glob(filepath.Join(dir, "frame-*.jpg"))
+	if err != nil || len(frames) == 0 {
+		cleanup()
+		return nil, nil, errors.New("failed to extract video frames: FFmpeg produced no frames")
+	}
+	sort.Strings(frames)
+
+	return frames, cleanup, nil
+}
+
+func encodeSprite(img image.Image, name string, opts imageOptions) (*pb.StoryboardSprite, error) {
+	outputPath := filepath.Join("thumbnails", fmt.Sprintf("%s-%s", uuid.New().String(), name))
+	defer removeIfExists(outputPath)
+
+	if err := encodeImage(outputPath, img, opts); err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated sprite: %v", err)
+	}
+
+	return &pb.StoryboardSprite{
+		Name:    name,
+		Width:   int32(img.Bounds().Dx()),
+		Height:  int32(img.Bounds().Dy()),
+		Content: content,
+	}, nil
+}
+
+// formatVttTime formats seconds as a WebVTT timestamp (hh:mm:ss.ttt).
+func formatVttTime(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second)).Round(time.Millisecond)
+	return fmt.Sprintf("%02d:%02d:%02d.%03d",
+		int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60, d.Milliseconds()%1000)
+}